@@ -2,6 +2,7 @@
 
 rm -fv Commander
 rm -fv Worker
+rm -fv herdctl
 
 protoc --go_out=plugins=grpc:. internal/src/pbMessages/messages.proto
 
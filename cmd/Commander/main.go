@@ -5,20 +5,68 @@ import (
 	"common"
 	"flag"
 	"fmt"
+	"log"
+	"store"
+	"strconv"
 	"sync"
 )
 
 func main() {
 	var debugFlag = flag.Bool("debug", false, "Enable debug logging")
+	var dataDir = flag.String("datadir", "herd-data", "Directory for the durable job store, empty keeps everything in memory")
+	var keepFinished = flag.Duration("keep-finished", store.KeepFinished, "How long finished jobs are kept in the job store")
+	priorityClasses := make(common.KeyValues)
+	flag.Var(priorityClasses, "priority-class", "Add or change a named priority jobs can be submitted with, as name=priority. May be repeated")
+	tenantWeights := make(common.KeyValues)
+	flag.Var(tenantWeights, "tenant-weight", "Share of worker slots a tenant is due relative to others, as tenant=weight, 1 if not given. May be repeated")
+	var halfLife = flag.Duration("usage-half-life", commander.UsageHalfLife, "How long until a tenant's past slot usage counts for half as much towards its share")
 	flag.Parse()
 	commander.DebugLog = *debugFlag
+	for name, value := range priorityClasses {
+		priority, err := strconv.Atoi(value)
+		if err != nil {
+			log.Fatalf("invalid priority %s for priority class %s", value, name)
+		}
+		commander.PriorityClasses[name] = priority
+	}
+	for tenant, value := range tenantWeights {
+		weight, err := strconv.ParseFloat(value, 64)
+		if err != nil || weight <= 0 {
+			log.Fatalf("invalid weight %s for tenant %s, expected a number above 0", value, tenant)
+		}
+		commander.TenantWeights[tenant] = weight
+	}
+	if *halfLife <= 0 {
+		log.Fatalf("-usage-half-life must be positive")
+	}
+	commander.UsageHalfLife = *halfLife
 
 	fmt.Println("Firing up the herd commander...")
 
-	common.SetupCloseHandler()
+	if *dataDir != "" {
+		store.KeepFinished = *keepFinished
+		fileStore, err := store.OpenFileStore(*dataDir)
+		if err != nil {
+			log.Fatalf("failed to open job store %s: %v", *dataDir, err)
+		}
+		commander.JobStore = fileStore
+	}
+
+	common.SetupCloseHandler(func() {
+		commander.Workers.CloseAll()
+		err := commander.JobStore.Close()
+		if err != nil {
+			log.Printf("ERROR: closing job store: %v\n", err)
+		}
+	})
 
 	commander.Workers = make(commander.WorkerMap)
 
+	err := commander.Restore()
+	if err != nil {
+		log.Fatalf("failed to restore from job store: %v", err)
+	}
+
 	var wg sync.WaitGroup
 
 	wg.Add(1)
@@ -30,8 +78,11 @@ func main() {
 	wg.Add(1)
 	go commander.RunWorkSender(&wg)
 
-	newJob := common.Job{"ls", nil, common.WAITING}
-	commander.Commands = append(commander.Commands, newJob)
+	wg.Add(1)
+	go commander.RunScheduler(&wg)
+
+	wg.Add(1)
+	go commander.RunTimers(&wg)
 
 	wg.Wait()
 
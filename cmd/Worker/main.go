@@ -7,6 +7,9 @@ package main
 import (
 	"common"
 	"flag"
+	"fmt"
+	"log"
+	"runtime"
 	"sync"
 	"worker"
 )
@@ -14,21 +17,55 @@ import (
 func main() {
 	var debugFlag = flag.Bool("debug", false, "Enable debug logging.")
 	var server = flag.String("server", "localhost", "Server to communicate with.")
+	var mode = flag.String("mode", "session", "How to take work from the server: \"session\" connects out to it, \"push\" listens for it to connect in, \"pull\" leases work from it.")
+	var slots = flag.Int("slots", runtime.NumCPU(), "Most jobs to run at once.")
+	var queue = flag.Int("queue", runtime.NumCPU(), "Most jobs to queue while every slot is busy, any more are turned away.")
+	var idFile = flag.String("idfile", "herd-worker-id", "File the worker's ID is kept in, created on first start.")
+	labels := make(common.KeyValues)
+	flag.Var(labels, "label", "Label the worker, as key=value, for jobs to select it by. May be repeated.")
 	flag.Parse()
+	if *slots < 1 {
+		log.Fatalf("-slots must be at least 1")
+	}
+	if *queue < 0 {
+		log.Fatalf("-queue must not be negative")
+	}
 	worker.DebugLog = *debugFlag
+	worker.Server = *server
+	worker.Slots = *slots
+	worker.QueueSize = *queue
+	worker.Labels = labels
 
-	common.SetupCloseHandler()
+	id, err := worker.LoadID(*idFile)
+	if err != nil {
+		log.Fatalf("failed to load worker ID from %s: %v", *idFile, err)
+	}
+	worker.ID = id
+	fmt.Printf("Herd worker %s\n", id)
+
+	common.SetupCloseHandler(worker.CloseConns)
 
 	var wg sync.WaitGroup
 
-	wg.Add(1)
-	go worker.RunHelloProtocol(*server, &wg)
+	switch *mode {
+	case "session":
+		wg.Add(1)
+		go worker.RunSession(*server, &wg)
+	case "push":
+		wg.Add(1)
+		go worker.RunHelloProtocol(*server, &wg)
 
-	wg.Add(1)
-	go worker.StartHeartbeatListener(&wg)
+		wg.Add(1)
+		go worker.StartHeartbeatListener(&wg)
 
-	wg.Add(1)
-	go worker.StartWorkerListener(&wg)
+		wg.Add(1)
+		go worker.StartWorkerListener(&wg)
+	case "pull":
+		wg.Add(1)
+		go worker.RunPullProtocol(*server, &wg)
+	default:
+		log.Fatalf("invalid mode %s", *mode)
+	}
 
 	wg.Wait()
 }
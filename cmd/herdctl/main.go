@@ -0,0 +1,674 @@
+package main
+
+import (
+	"common"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"pbMessages"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func usage(errmsg string) {
+	fmt.Fprintf(os.Stderr,
+		"%s\n\n"+
+			"usage: %s [-server <host>] <command> [arguments]\n"+
+			"       where <command> is one of\n"+
+			"       submit [options] <cmd> [args...], jobs, job <id>, logs [-f] <id>,\n"+
+			"       cancel <id>, submit-workflow <file>, workflows, workflow <id>,\n"+
+			"       cancel-workflow <id>, add-schedule [options] <cron> [submit options] <cmd> [args...],\n"+
+			"       schedules, schedule <id>, remove-schedule <id>, workers,\n"+
+			"       remove-worker <worker id> or tenants.\n",
+		errmsg, os.Args[0])
+	os.Exit(2)
+}
+
+func parseJobID(args []string) int32 {
+	if len(args) != 1 {
+		usage("expected a single job ID")
+	}
+	id, err := strconv.ParseInt(args[0], 10, 32)
+	if err != nil {
+		usage(fmt.Sprintf("invalid job ID %s", args[0]))
+	}
+	return int32(id)
+}
+
+// parseDispatch turns the -dispatch flag of the submit command into a dispatch
+// mode and worker count
+func parseDispatch(dispatch string) (pbMessages.DispatchMode, int32) {
+	switch strings.ToLower(dispatch) {
+	case "one":
+		return pbMessages.DispatchMode_DISPATCH_ONE, 0
+	case "all":
+		return pbMessages.DispatchMode_DISPATCH_ALL, 0
+	}
+	n, err := strconv.ParseInt(dispatch, 10, 32)
+	if err != nil || n < 1 {
+		usage(fmt.Sprintf("invalid dispatch %s, expected one, all or a number of workers", dispatch))
+	}
+	return pbMessages.DispatchMode_DISPATCH_N, int32(n)
+}
+
+// parseSubmit turns the arguments of the submit command into a request
+func parseSubmit(args []string) *pbMessages.SubmitJobRequest {
+	flags := flag.NewFlagSet("submit", flag.ExitOnError)
+	timeout := flags.Duration("timeout", 0, "Stop the job if it runs for longer than this.")
+	killGrace := flags.Duration("kill-grace", 0, "Time between SIGTERM and SIGKILL when the job is stopped.")
+	attempts := flags.Int("attempts", 1, "Run the job up to this many times until it succeeds.")
+	backoff := flags.Duration("backoff", time.Second, "Delay before the first retry, doubled for each one after.")
+	backoffCap := flags.Duration("backoff-cap", 0, "Longest delay between retries, 0 for a day.")
+	jitter := flags.Float64("jitter", 0, "Fraction of the retry delay to randomise by.")
+	exitCodes := flags.String("retry-exit-codes", "", "Comma separated exit codes worth retrying, any failure if empty.")
+	elsewhere := flags.Bool("retry-elsewhere", false, "Retry on a different worker where possible.")
+	workingDir := flags.String("dir", "", "Directory to run the job in.")
+	runAt := flags.String("run-at", "", "Hold the job back until this time, in RFC 3339 format e.g. 2024-01-02T15:04:05Z.")
+	delay := flags.Duration("delay", 0, "Hold the job back for this long, instead of -run-at.")
+	ttl := flags.Duration("ttl", 0, "Let the job expire instead of running if it has not been sent out this long after it is due.")
+	env := make(common.KeyValues)
+	flags.Var(env, "env", "Set an environment variable for the job, as NAME=value. May be repeated.")
+	labels := make(common.KeyValues)
+	flags.Var(labels, "label", "Label the job, as key=value. May be repeated.")
+	selector := flags.String("selector", "", "Workers the job may run on, e.g. 'disk=ssd,arch in (amd64,arm64),!gpu'.")
+	tenant := flags.String("tenant", "", "Team the job is run for, which shares worker slots fairly with the others.")
+	priority := flags.Int("priority", 0, "Higher priority jobs run first, and may preempt lower priority ones.")
+	priorityClass := flags.String("priority-class", "", "Named priority to run the job with instead of -priority, e.g. high.")
+	dispatch := flags.String("dispatch", "one", "Run the job on \"one\" worker, on \"all\" matching workers or on this many distinct workers.")
+	cpus := flags.Float64("cpus", 0, "CPUs the job needs.")
+	var memory, disk common.ByteSize
+	flags.Var(&memory, "memory", "Memory the job needs, e.g. 512M.")
+	flags.Var(&disk, "disk", "Disk space the job needs, e.g. 10G.")
+	flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		usage("no job command specified")
+	}
+	sel, err := common.ParseSelector(*selector)
+	if err != nil {
+		usage(err.Error())
+	}
+	mode, count := parseDispatch(*dispatch)
+	request := &pbMessages.SubmitJobRequest{
+		Delay:         int64(*delay),
+		Ttl:           int64(*ttl),
+		Dispatch:      mode,
+		Count:         count,
+		Priority:      int32(*priority),
+		PriorityClass: *priorityClass,
+		Tenant:        *tenant,
+		Spec: &pbMessages.Job{
+			Command:    flags.Arg(0),
+			Args:       flags.Args()[1:],
+			Env:        env,
+			WorkingDir: *workingDir,
+			Timeout:    int64(*timeout),
+			KillGrace:  int64(*killGrace),
+			Labels:     labels,
+			Selector:   sel.Spec(),
+			Resources: &pbMessages.Resources{
+				Cpus:   *cpus,
+				Memory: int64(memory),
+				Disk:   int64(disk),
+			},
+		},
+		Retry: &pbMessages.RetryPolicy{
+			MaxAttempts:     int32(*attempts),
+			BackoffBase:     int64(*backoff),
+			BackoffCap:      int64(*backoffCap),
+			Jitter:          *jitter,
+			DifferentWorker: *elsewhere,
+		},
+	}
+	for _, code := range strings.Split(*exitCodes, ",") {
+		if code == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(code, 10, 32)
+		if err != nil {
+			usage(fmt.Sprintf("invalid exit code %s", code))
+		}
+		request.Retry.RetryableExitCodes = append(request.Retry.RetryableExitCodes, int32(n))
+	}
+	if *runAt != "" {
+		t, err := time.Parse(time.RFC3339, *runAt)
+		if err != nil {
+			usage(fmt.Sprintf("invalid time to run at %s, expected RFC 3339 format", *runAt))
+		}
+		request.RunAt = t.UnixNano()
+	}
+	return request
+}
+
+// workflowFile is how a workflow is written down for submit-workflow, e.g.
+//
+//	{
+//	  "onFailure": "skip",
+//	  "jobs": [
+//	    {"name": "build", "submit": ["-timeout", "10m", "make"]},
+//	    {"name": "test", "dependsOn": ["build"], "submit": ["make", "test"]}
+//	  ]
+//	}
+//
+// where each job's submit arguments are those of the submit command
+type workflowFile struct {
+	OnFailure string // what happens to jobs depending on one which did not succeed, skip or fail
+	Jobs      []struct {
+		Name      string
+		DependsOn []string
+		Submit    []string
+	}
+}
+
+// parseWorkflow turns the workflow file given to the submit-workflow command
+// into a request
+func parseWorkflow(args []string) *pbMessages.SubmitWorkflowRequest {
+	if len(args) != 1 {
+		usage("expected a single workflow file")
+	}
+	data, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		log.Fatalf("failed to read workflow: %v", err)
+	}
+	var file workflowFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		log.Fatalf("failed to read workflow %s: %v", args[0], err)
+	}
+
+	request := &pbMessages.SubmitWorkflowRequest{}
+	switch strings.ToLower(file.OnFailure) {
+	case "", "skip":
+		request.OnFailure = pbMessages.FailurePolicy_SKIP_DEPENDENTS
+	case "fail":
+		request.OnFailure = pbMessages.FailurePolicy_FAIL_DEPENDENTS
+	default:
+		usage(fmt.Sprintf("invalid onFailure %s, expected skip or fail", file.OnFailure))
+	}
+	for _, job := range file.Jobs {
+		request.Jobs = append(request.Jobs, &pbMessages.WorkflowJob{
+			Name:      job.Name,
+			DependsOn: job.DependsOn,
+			Job:       parseSubmit(job.Submit),
+		})
+	}
+	return request
+}
+
+// parseSchedule turns the arguments of the add-schedule command into a
+// request, the cron expression being followed by the job as given to submit
+func parseSchedule(args []string) *pbMessages.CreateScheduleRequest {
+	flags := flag.NewFlagSet("add-schedule", flag.ExitOnError)
+	name := flags.String("name", "", "Name of the schedule.")
+	timeZone := flags.String("tz", "", "Time zone to read the cron expression in, e.g. Europe/London, UTC if empty.")
+	overlap := flags.String("overlap", "allow", "When a run is due while the last job is still going \"allow\" another, \"skip\" the run or \"replace\" the job.")
+	catchUp := flags.Bool("catch-up", false, "Run once straight away if runs were missed while the commander was down.")
+	flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		usage("no cron expression specified")
+	}
+	request := &pbMessages.CreateScheduleRequest{
+		Name:     *name,
+		Cron:     flags.Arg(0),
+		TimeZone: *timeZone,
+		CatchUp:  *catchUp,
+		Job:      parseSubmit(flags.Args()[1:]),
+	}
+	switch strings.ToLower(*overlap) {
+	case "allow":
+		request.Overlap = pbMessages.OverlapPolicy_OVERLAP_ALLOW
+	case "skip":
+		request.Overlap = pbMessages.OverlapPolicy_OVERLAP_SKIP
+	case "replace":
+		request.Overlap = pbMessages.OverlapPolicy_OVERLAP_REPLACE
+	default:
+		usage(fmt.Sprintf("invalid overlap %s, expected allow, skip or replace", *overlap))
+	}
+	return request
+}
+
+// formatBytes prints a size the way -memory and -disk take it
+func formatBytes(size int64) string {
+	suffixes := []string{"", "K", "M", "G", "T"}
+	value, i := float64(size), 0
+	for value >= 1024 && i < len(suffixes)-1 {
+		value /= 1024
+		i += 1
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", value), ".0") + suffixes[i]
+}
+
+// printWorkers lists workers with what they have to run jobs with, how much of
+// it is reserved by the jobs sent to them and how busy their slots are
+func printWorkers(workers ...*pbMessages.WorkerInfo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tIP\tHOSTNAME\tSTATUS\tMODE\tNETWORK ERRORS\tVERSION\tFEATURES\tLABELS\t"+
+		"PLATFORM\tJOBS\tSLOTS USED\tQUEUED\tCPUS RESERVED\tMEMORY FREE\tDISK FREE\tADDRESSES")
+	for _, worker := range workers {
+		res := worker.Resources
+		slots := "-"
+		if res.GetSlots() > 0 {
+			slots = strconv.Itoa(int(res.GetSlots()))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\t%v\t%s/%s\t%d\t%d/%s\t%d/%d\t%v/%d\t%s/%s\t%s/%s\t%s\n",
+			worker.Id, worker.Ip, worker.Fqdn, worker.Status, worker.Mode,
+			worker.NetworkErrors, worker.ProtocolVersion, strings.Join(worker.Features, ","), common.KeyValues(worker.Labels),
+			res.GetOs(), res.GetArch(), worker.Jobs, res.GetSlotsUsed(), slots, res.GetQueued(), res.GetQueueSize(), worker.Reserved.GetCpus(), res.GetCpus(),
+			formatBytes(res.GetFreeMemory()), formatBytes(res.GetTotalMemory()),
+			formatBytes(res.GetFreeDisk()), formatBytes(res.GetTotalDisk()),
+			strings.Join(worker.Addresses, ","))
+	}
+	w.Flush()
+}
+
+func printJobs(jobs ...*pbMessages.JobInfo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tTENANT\tPRIORITY\tCOMMAND")
+	for _, job := range jobs {
+		cmdline := strings.Join(append([]string{job.Command}, job.Args...), " ")
+		fmt.Fprintf(w, "%d\t%v\t%s\t%d\t%s\n", job.JobID, common.Status(job.Status), job.Tenant, job.Priority, cmdline)
+	}
+	w.Flush()
+}
+
+func printJobDetail(job *pbMessages.JobInfo) {
+	fmt.Println()
+	if job.Worker != "" {
+		fmt.Printf("Worker: %s\n", job.Worker)
+	}
+	if job.PriorityClass != "" {
+		fmt.Printf("Priority class: %s\n", job.PriorityClass)
+	}
+	if job.PreemptedBy != 0 {
+		fmt.Printf("Being preempted by job: %d\n", job.PreemptedBy)
+	}
+	if job.Parent != 0 {
+		fmt.Printf("Part of job: %d\n", job.Parent)
+	}
+	if job.Schedule != 0 {
+		fmt.Printf("Created by schedule: %d\n", job.Schedule)
+	}
+	if job.Workflow != 0 {
+		fmt.Printf("Workflow: %d, as %s\n", job.Workflow, job.Name)
+	}
+	if len(job.DependsOn) > 0 {
+		fmt.Printf("Depends on: %s\n", formatIDs(job.DependsOn))
+	}
+	switch job.Dispatch {
+	case pbMessages.DispatchMode_DISPATCH_ALL:
+		fmt.Println("Dispatch: all matching workers")
+	case pbMessages.DispatchMode_DISPATCH_N:
+		fmt.Printf("Dispatch: %d workers\n", job.Count)
+	}
+	spec := job.Spec
+	if spec.WorkingDir != "" {
+		fmt.Printf("Directory: %s\n", spec.WorkingDir)
+	}
+	for key, value := range spec.Env {
+		fmt.Printf("Env: %s=%s\n", key, value)
+	}
+	for key, value := range spec.Labels {
+		fmt.Printf("Label: %s=%s\n", key, value)
+	}
+	if len(spec.Selector) > 0 {
+		fmt.Printf("Selector: %v\n", common.SelectorFromSpec(spec.Selector))
+	}
+	if res := spec.Resources; res.GetCpus() > 0 || res.GetMemory() > 0 || res.GetDisk() > 0 {
+		fmt.Printf("Resources: %v CPUs, %s memory, %s disk\n", res.GetCpus(), formatBytes(res.GetMemory()), formatBytes(res.GetDisk()))
+	}
+	if spec.Timeout > 0 {
+		fmt.Printf("Timeout: %v\n", time.Duration(spec.Timeout))
+	}
+	if common.Status(job.Status).Finished() && common.Status(job.Status) != common.CANCELLED && job.FanOut == nil {
+		fmt.Printf("Exit code: %d\n", job.ExitCode)
+		if job.Signal != "" {
+			fmt.Printf("Killed by signal: %s\n", job.Signal)
+		}
+		if job.Error != "" {
+			fmt.Printf("Error: %s\n", job.Error)
+		}
+	}
+	if job.NotBefore != 0 && common.Status(job.Status) == common.WAITING {
+		fmt.Printf("Retrying after: %s\n", time.Unix(0, job.NotBefore).Format(time.RFC3339Nano))
+	}
+	if job.RunAt != 0 {
+		fmt.Printf("Run at: %s\n", time.Unix(0, job.RunAt).Format(time.RFC3339Nano))
+	}
+	if job.ExpiresAt != 0 && len(job.Attempts) == 0 && !common.Status(job.Status).Finished() {
+		fmt.Printf("Expires at: %s, unless sent out by then\n", time.Unix(0, job.ExpiresAt).Format(time.RFC3339Nano))
+	}
+	if job.LeaseEnd != 0 {
+		fmt.Printf("Lease ends: %s\n", time.Unix(0, job.LeaseEnd).Format(time.RFC3339Nano))
+	}
+	if fanOut := job.FanOut; fanOut != nil {
+		fmt.Printf("\nJobs: %d, %d pending, %d running, %d succeeded, %d failed, %d cancelled\n",
+			fanOut.Total, fanOut.Pending, fanOut.Running, fanOut.Succeeded, fanOut.Failed, fanOut.Cancelled)
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "  ID\tWORKER\tSTATUS\tEXIT CODE\tERROR")
+		for _, result := range fanOut.Results {
+			fmt.Fprintf(w, "  %d\t%s\t%v\t%d\t%s\n", result.JobID, result.Worker, common.Status(result.Status), result.ExitCode, result.Error)
+		}
+		w.Flush()
+	}
+	if len(job.Attempts) > 0 {
+		fmt.Println("\nAttempts:")
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "  #\tWORKER\tSTATUS\tEXIT CODE\tDURATION\tPREEMPTED BY")
+		for _, attempt := range job.Attempts {
+			duration := time.Duration(attempt.EndTime - attempt.StartTime)
+			preemptedBy := ""
+			if attempt.PreemptedBy != 0 {
+				preemptedBy = strconv.Itoa(int(attempt.PreemptedBy))
+			}
+			fmt.Fprintf(w, "  %d\t%s\t%v\t%d\t%v\t%s\n", attempt.Number, attempt.Worker, common.Status(attempt.Status), attempt.ExitCode, duration, preemptedBy)
+		}
+		w.Flush()
+	}
+	fmt.Println("\nHistory:")
+	for _, transition := range job.History {
+		when := time.Unix(0, transition.Time).Format(time.RFC3339Nano)
+		if transition.Reason != "" {
+			fmt.Printf("  %-35s %-13v %s\n", when, common.Status(transition.Status), transition.Reason)
+		} else {
+			fmt.Printf("  %-35s %v\n", when, common.Status(transition.Status))
+		}
+	}
+}
+
+// formatIDs prints a list of job IDs
+func formatIDs(ids []int32) string {
+	var s []string
+	for _, id := range ids {
+		s = append(s, strconv.Itoa(int(id)))
+	}
+	return strings.Join(s, ",")
+}
+
+// printWorkflows lists workflows with how many of their jobs have got where
+func printWorkflows(workflows ...*pbMessages.WorkflowInfo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tON FAILURE\tJOBS\tSUCCEEDED\tFAILED\tSKIPPED\tCANCELLED")
+	for _, workflow := range workflows {
+		counts := make(map[common.Status]int)
+		for _, job := range workflow.Jobs {
+			counts[common.Status(job.Status)] += 1
+		}
+		fmt.Fprintf(w, "%d\t%v\t%v\t%d\t%d\t%d\t%d\t%d\n", workflow.WorkflowID, common.Status(workflow.Status),
+			common.FailurePolicy(workflow.OnFailure), len(workflow.Jobs), counts[common.SUCCESS],
+			counts[common.FAILED]+counts[common.TIMED_OUT]+counts[common.EXPIRED], counts[common.SKIPPED], counts[common.CANCELLED])
+	}
+	w.Flush()
+}
+
+// printWorkflowJobs lists the jobs in a workflow with what they depend on
+func printWorkflowJobs(workflow *pbMessages.WorkflowInfo) {
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "  ID\tNAME\tSTATUS\tDEPENDS ON\tWORKER\tCOMMAND")
+	for _, job := range workflow.Jobs {
+		cmdline := strings.Join(append([]string{job.Command}, job.Args...), " ")
+		fmt.Fprintf(w, "  %d\t%s\t%v\t%s\t%s\t%s\n", job.JobID, job.Name, common.Status(job.Status), formatIDs(job.DependsOn), job.Worker, cmdline)
+	}
+	w.Flush()
+}
+
+// formatTime prints a time sent as Unix nanoseconds, or - for none
+func formatTime(t int64) string {
+	if t == 0 {
+		return "-"
+	}
+	return time.Unix(0, t).Format(time.RFC3339)
+}
+
+// printSchedules lists schedules with when they run and how they have got on
+func printSchedules(schedules ...*pbMessages.ScheduleInfo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tCRON\tTIME ZONE\tOVERLAP\tNEXT RUN\tLAST RUN\tJOBS\tSKIPPED\tMISSED\tCOMMAND")
+	for _, schedule := range schedules {
+		timeZone := schedule.TimeZone
+		if timeZone == "" {
+			timeZone = "UTC"
+		}
+		cmdline := strings.Join(append([]string{schedule.Job.Command}, schedule.Job.Args...), " ")
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%v\t%s\t%s\t%d\t%d\t%d\t%s\n", schedule.ScheduleID, schedule.Name, schedule.Cron, timeZone,
+			common.OverlapPolicy(schedule.Overlap), formatTime(schedule.NextRun), formatTime(schedule.LastRun),
+			len(schedule.Jobs), schedule.Skipped, schedule.Missed, cmdline)
+	}
+	w.Flush()
+}
+
+// printScheduleDetail shows the jobs a schedule created most recently and the
+// runs it missed
+func printScheduleDetail(schedule *pbMessages.ScheduleInfo) {
+	fmt.Println()
+	fmt.Printf("Created: %s\n", formatTime(schedule.Created))
+	if schedule.CatchUp {
+		fmt.Println("Catches up on missed runs")
+	}
+	if len(schedule.Jobs) > 0 {
+		fmt.Printf("Recent jobs: %s\n", formatIDs(schedule.Jobs))
+	}
+	if len(schedule.MissedAt) > 0 {
+		fmt.Println("\nMissed runs:")
+		for _, due := range schedule.MissedAt {
+			fmt.Printf("  %s\n", formatTime(due))
+		}
+	}
+}
+
+// parseWorkflowID reads the workflow ID given to a command
+func parseWorkflowID(args []string) int32 {
+	if len(args) != 1 {
+		usage("expected a single workflow ID")
+	}
+	id, err := strconv.ParseInt(args[0], 10, 32)
+	if err != nil {
+		usage(fmt.Sprintf("invalid workflow ID %s", args[0]))
+	}
+	return int32(id)
+}
+
+// parseScheduleID reads the schedule ID given to a command
+func parseScheduleID(args []string) int32 {
+	if len(args) != 1 {
+		usage("expected a single schedule ID")
+	}
+	id, err := strconv.ParseInt(args[0], 10, 32)
+	if err != nil {
+		usage(fmt.Sprintf("invalid schedule ID %s", args[0]))
+	}
+	return int32(id)
+}
+
+// tailJob prints a job's output as it is produced, reconnecting from where it
+// left off if the stream is interrupted while following
+func tailJob(client pbMessages.OutputServiceClient, jobID int32, follow bool) {
+	request := &pbMessages.RequestStdOut{JobID: jobID, Follow: follow}
+	for {
+		stream, err := client.StreamOutput(context.Background(), request)
+		for err == nil {
+			var chunk *pbMessages.ResponseStdOut
+			chunk, err = stream.Recv()
+			if err != nil {
+				break
+			}
+
+			w, offset := os.Stdout, &request.StdoutOffset
+			if chunk.Stream == pbMessages.OutputStream_STDERR {
+				w, offset = os.Stderr, &request.StderrOffset
+			}
+			// skip anything already printed before a reconnect
+			data := chunk.Data
+			end := chunk.Offset + int64(len(data))
+			if end <= *offset {
+				continue
+			}
+			if chunk.Offset < *offset {
+				data = data[*offset-chunk.Offset:]
+			}
+			// the worker only keeps the most recent output of a chatty job
+			if chunk.Offset > *offset {
+				fmt.Fprintf(os.Stderr, "[%d bytes of output no longer kept]\n", chunk.Offset-*offset)
+			}
+			fmt.Fprint(w, data)
+			*offset = end
+		}
+
+		if err == io.EOF {
+			return
+		}
+		if !follow || status.Code(err) != codes.Unavailable {
+			log.Fatalf("failed to stream output: %v", err)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+func main() {
+	var server = flag.String("server", "localhost", "Commander to communicate with.")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		usage("no command specified")
+	}
+
+	opts := grpc.WithInsecure()
+	cc, err := grpc.Dial(fmt.Sprintf("%s:50050", *server), opts)
+	if err != nil {
+		log.Fatalf("gRPC dial error: %v", err)
+	}
+	defer cc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	jobclient := pbMessages.NewJobServiceClient(cc)
+	clusterclient := pbMessages.NewClusterServiceClient(cc)
+	outputclient := pbMessages.NewOutputServiceClient(cc)
+	scheduleclient := pbMessages.NewScheduleServiceClient(cc)
+
+	cmd := strings.ToLower(flag.Arg(0))
+	args := flag.Args()[1:]
+	switch cmd {
+	case "submit":
+		response, err := jobclient.SubmitJob(ctx, parseSubmit(args))
+		if err != nil {
+			log.Fatalf("failed to submit job: %v", err)
+		}
+		fmt.Println(response.JobID)
+	case "jobs":
+		response, err := jobclient.ListJobs(ctx, &pbMessages.ListJobsRequest{})
+		if err != nil {
+			log.Fatalf("failed to list jobs: %v", err)
+		}
+		printJobs(response.Jobs...)
+	case "job":
+		response, err := jobclient.GetJob(ctx, &pbMessages.GetJobRequest{JobID: parseJobID(args)})
+		if err != nil {
+			log.Fatalf("failed to get job: %v", err)
+		}
+		printJobs(response)
+		printJobDetail(response)
+	case "logs":
+		follow := len(args) > 0 && args[0] == "-f"
+		if follow {
+			args = args[1:]
+		}
+		tailJob(outputclient, parseJobID(args), follow)
+	case "cancel":
+		response, err := jobclient.CancelJob(ctx, &pbMessages.CancelJobRequest{JobID: parseJobID(args)})
+		if err != nil {
+			log.Fatalf("failed to cancel job: %v", err)
+		}
+		printJobs(response)
+	case "submit-workflow":
+		response, err := jobclient.SubmitWorkflow(ctx, parseWorkflow(args))
+		if err != nil {
+			log.Fatalf("failed to submit workflow: %v", err)
+		}
+		fmt.Println(response.WorkflowID)
+	case "workflows":
+		response, err := jobclient.ListWorkflows(ctx, &pbMessages.ListWorkflowsRequest{})
+		if err != nil {
+			log.Fatalf("failed to list workflows: %v", err)
+		}
+		printWorkflows(response.Workflows...)
+	case "workflow":
+		response, err := jobclient.GetWorkflow(ctx, &pbMessages.GetWorkflowRequest{WorkflowID: parseWorkflowID(args)})
+		if err != nil {
+			log.Fatalf("failed to get workflow: %v", err)
+		}
+		printWorkflows(response)
+		printWorkflowJobs(response)
+	case "cancel-workflow":
+		response, err := jobclient.CancelWorkflow(ctx, &pbMessages.CancelWorkflowRequest{WorkflowID: parseWorkflowID(args)})
+		if err != nil {
+			log.Fatalf("failed to cancel workflow: %v", err)
+		}
+		printWorkflows(response)
+	case "add-schedule":
+		response, err := scheduleclient.CreateSchedule(ctx, parseSchedule(args))
+		if err != nil {
+			log.Fatalf("failed to add schedule: %v", err)
+		}
+		fmt.Println(response.ScheduleID)
+	case "schedules":
+		response, err := scheduleclient.ListSchedules(ctx, &pbMessages.ListSchedulesRequest{})
+		if err != nil {
+			log.Fatalf("failed to list schedules: %v", err)
+		}
+		printSchedules(response.Schedules...)
+	case "schedule":
+		response, err := scheduleclient.GetSchedule(ctx, &pbMessages.GetScheduleRequest{ScheduleID: parseScheduleID(args)})
+		if err != nil {
+			log.Fatalf("failed to get schedule: %v", err)
+		}
+		printSchedules(response)
+		printScheduleDetail(response)
+	case "remove-schedule":
+		_, err := scheduleclient.RemoveSchedule(ctx, &pbMessages.RemoveScheduleRequest{ScheduleID: parseScheduleID(args)})
+		if err != nil {
+			log.Fatalf("failed to remove schedule: %v", err)
+		}
+	case "workers":
+		response, err := clusterclient.ListWorkers(ctx, &pbMessages.ListWorkersRequest{})
+		if err != nil {
+			log.Fatalf("failed to list workers: %v", err)
+		}
+		printWorkers(response.Workers...)
+	case "remove-worker":
+		if len(args) != 1 {
+			usage("expected a single worker ID")
+		}
+		_, err := clusterclient.RemoveWorker(ctx, &pbMessages.RemoveWorkerRequest{Id: args[0]})
+		if err != nil {
+			log.Fatalf("failed to remove worker: %v", err)
+		}
+	case "tenants":
+		response, err := clusterclient.ListTenants(ctx, &pbMessages.ListTenantsRequest{})
+		if err != nil {
+			log.Fatalf("failed to list tenants: %v", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "TENANT\tWEIGHT\tRUNNING\tWAITING\tSHARE\tFAIR SHARE\tUSAGE")
+		for _, tenant := range response.Tenants {
+			fmt.Fprintf(w, "%s\t%v\t%d\t%d\t%.0f%%\t%.0f%%\t%v\n", tenant.Name, tenant.Weight, tenant.Running, tenant.Waiting,
+				tenant.Share*100, tenant.FairShare*100, time.Duration(tenant.Usage*float64(time.Second)).Round(time.Second))
+		}
+		w.Flush()
+	default:
+		usage(fmt.Sprintf("invalid command %s", cmd))
+	}
+}
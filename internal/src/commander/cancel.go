@@ -0,0 +1,171 @@
+package commander
+
+import (
+	"common"
+	"context"
+	"fmt"
+	"log"
+	"pbMessages"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// This function implements the CancelJob interface. Queued jobs are cancelled
+// straight away, jobs which have been sent out are cancelled by their worker,
+// which reports them CANCELLED once their process has gone.
+func (*commander) CancelJob(ctx context.Context, request *pbMessages.CancelJobRequest) (*pbMessages.JobInfo, error) {
+	job := GetJob(request.GetJobID())
+	if job == nil {
+		return nil, status.Errorf(codes.NotFound, "job %d not found", request.GetJobID())
+	}
+
+	err := cancelJob(job)
+	if err != nil {
+		return nil, err
+	}
+	return jobInfo(job), nil
+}
+
+// cancelJob cancels a job, and every job it was fanned out to
+func cancelJob(job *common.Job) error {
+	if TransitionJob(job, common.WAITING, common.CANCELLED) || TransitionJob(job, common.UNSCHEDULABLE, common.CANCELLED) ||
+		TransitionJob(job, common.BLOCKED, common.CANCELLED) || TransitionJob(job, common.DELAYED, common.CANCELLED) {
+		if DebugLog {
+			fmt.Printf("Cancelled job %d\n", job.ID)
+		}
+		return nil
+	}
+
+	CommandsMtx.Lock()
+	stat := job.Status
+	host := job.Worker
+	var children []*common.Job
+	if !stat.Finished() {
+		job.CancelRequested = true
+		saveJob(job)
+		for _, id := range job.Children {
+			child := findJob(id)
+			if child != nil && !child.Status.Finished() {
+				children = append(children, child)
+			}
+		}
+	}
+	CommandsMtx.Unlock()
+
+	if stat.Finished() {
+		return status.Errorf(codes.FailedPrecondition, "job %d is %v and can no longer be cancelled", job.ID, stat)
+	}
+	if job.FanOut() {
+		// the job finishes as CANCELLED once its children have
+		for _, child := range children {
+			err := cancelJob(child)
+			if err != nil {
+				log.Printf("Cancelling job %d of job %d failed: %v\n", child.ID, job.ID, err)
+			}
+		}
+		return nil
+	}
+	return forwardCancel(job, host)
+}
+
+// cancelRequested reports whether CancelJob has been called for a job
+func cancelRequested(job *common.Job) bool {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	return job.CancelRequested
+}
+
+// forwardCancel asks the worker a job was sent to to cancel it
+func forwardCancel(job *common.Job, host string) error {
+	if !Workers.HasFeature(host, common.FEATURE_CANCEL) {
+		return status.Errorf(codes.Unimplemented, "worker %s does not support cancelling jobs", host)
+	}
+	if Workers.IsPull(host) {
+		// there is no reaching it, RenewLease tells it to stop the job
+		if DebugLog {
+			fmt.Printf("Job %d will be cancelled when %s next renews its lease\n", job.ID, host)
+		}
+		return nil
+	}
+	pMessage := &pbMessages.CancelJobRequest{JobID: job.ID}
+	err := SendCancelMessage(host, pMessage)
+	if status.Code(err) == codes.NotFound {
+		// the worker has never heard of it, so there is nothing to stop. If
+		// the Work message is still on its way RunWorkSender will send the
+		// cancel again once it arrives. A job being preempted is requeued.
+		CommandsMtx.Lock()
+		running := job.Status == common.RUNNING
+		finishJob(job, common.Attempt{
+			Status: common.CANCELLED,
+			Error:  fmt.Sprintf("%s was not running it", host),
+			Start:  job.StatusTime(common.RUNNING),
+			End:    time.Now(),
+		})
+		CommandsMtx.Unlock()
+		if running && DebugLog {
+			fmt.Printf("Cancelled job %d, %s was not running it\n", job.ID, host)
+		}
+		return nil
+	}
+	if status.Code(err) == codes.FailedPrecondition {
+		// it has finished and is being reported
+		if DebugLog {
+			fmt.Printf("Job %d had already finished on %s\n", job.ID, host)
+		}
+		return nil
+	}
+	if err != nil {
+		log.Printf("Cancelling job %d on %s failed: %v\n", job.ID, host, err)
+		return status.Errorf(codes.Unavailable, "cannot reach worker %s to cancel job %d: %v", host, job.ID, err)
+	}
+	if DebugLog {
+		fmt.Printf("Asked %s to cancel job %d\n", host, job.ID)
+	}
+	return nil
+}
+
+// retractJob makes sure a worker does not run a job whose Work message may or
+// may not have reached it, returning false if the worker cannot be asked or
+// has already run the job
+func retractJob(job *common.Job, host string) bool {
+	if !Workers.HasFeature(host, common.FEATURE_CANCEL) {
+		return false
+	}
+	pMessage := &pbMessages.CancelJobRequest{JobID: job.ID}
+	err := SendCancelMessage(host, pMessage)
+	if err != nil && status.Code(err) != codes.NotFound {
+		log.Printf("Cannot take job %d back from %s: %v\n", job.ID, host, err)
+		return false
+	}
+	if DebugLog {
+		fmt.Printf("Took job %d back from %s\n", job.ID, host)
+	}
+	return true
+}
+
+func SendCancelMessage(host string, message *pbMessages.CancelJobRequest) error {
+	networkclient, err := Workers.WorkClient(host)
+	if err != nil {
+		if DebugLog {
+			log.Printf("gRPC dial error: %v\n", err)
+		}
+		return err
+	}
+
+	response, err := networkclient.Cancel(context.Background(), message)
+	if err != nil {
+		if DebugLog {
+			log.Printf("SendCancelMessage() failed: %v\n", err)
+		}
+		return err
+	} else {
+		if response != nil {
+			if DebugLog {
+				fmt.Printf("Sent 'CancelJobRequest' to 'Cancel' service, received 'WorkResponse'\n")
+			}
+		}
+	}
+	return nil
+}
@@ -13,37 +13,136 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
-	DebugLog   bool
-	Commands   []common.Job
-	Workers    WorkerMap
-	WorkersMtx sync.Mutex
+	DebugLog    bool
+	Commands    []*common.Job
+	CommandsMtx sync.Mutex
+	Workers     WorkerMap
+	WorkersMtx  sync.Mutex
+	lastJobID   int32
+
+	lastWorkflowID int32
+
+	// poked by wakeWorkSender
+	workReady = make(chan struct{}, 1)
+)
+
+// Range of protocol versions the commander can talk to workers with
+const (
+	minWorkVersion = common.PROTOCOL_V1
+	workVersion    = common.PROTOCOL_V3
 )
 
-const workVersion = 1
+// How long a worker which turned work away is left alone for, unless a
+// heartbeat shows it has a free slot sooner
+const busyBackoff = 10 * time.Second
+
+// How long a worker in push or session mode can be OFFLINE before the jobs it
+// was sent are taken back from it
+const workerLostAfter = 2 * time.Minute
+
+// Features the commander knows how to use
+var workFeatures = []string{common.FEATURE_CANCEL, common.FEATURE_OUTPUT_STREAM}
 
 type commander struct {
 }
 
-// This function implements the Hello interface
+// This function implements the Hello interface, used by workers in push mode
 func (*commander) Hello(ctx context.Context, request *pbMessages.HelloRequest) (*pbMessages.HelloResponse, error) {
-	ver := request.GetVersion()
-	if ver == 1 {
-		Workers.AddWorker(request.GetIp())
-	}
 	if DebugLog {
-		fmt.Printf("Received Hello message from %s [%s]\n", request.GetIp(), request.GetFqdn())
+		fmt.Printf("Received Hello message from %s at %s [%s]\n", workerID(request), request.GetIp(), request.GetFqdn())
+	}
+
+	response, err := negotiate(request)
+	if err != nil {
+		return nil, err
+	}
+	if request.GetMode() == "pull" {
+		Workers.AddPullWorker(request, response)
+	} else {
+		Workers.AddWorker(request, response)
+	}
+	return response, nil
+}
+
+// negotiate picks the highest protocol version both sides speak, turning away
+// workers with none in common
+func negotiate(request *pbMessages.HelloRequest) (*pbMessages.HelloResponse, error) {
+	offered := request.GetSupportedVersions()
+	if len(offered) == 0 {
+		// older workers only send the one version they speak
+		offered = []int32{request.GetVersion()}
+	}
+	ver := common.NegotiateVersion(offered, minWorkVersion, workVersion)
+	if ver == 0 {
+		log.Printf("Rejected worker %s at %s speaking protocol versions %v\n", workerID(request), request.GetIp(), offered)
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"worker speaks protocol versions %v but this commander needs a version from %d to %d", offered, minWorkVersion, workVersion)
 	}
 
 	response := &pbMessages.HelloResponse{
-		Version: 1,
+		Version:  ver,
+		Features: common.CommonFeatures(workFeatures, request.GetFeatures()),
+	}
+	return response, nil
+}
+
+// This function implements the ReportStatus interface, workers call it when a
+// job they were sent starts running and again when it finishes
+func (*commander) ReportStatus(ctx context.Context, request *pbMessages.JobStatusReport) (*pbMessages.JobStatusAck, error) {
+	job := GetJob(request.GetJobID())
+	if job == nil {
+		return nil, status.Errorf(codes.NotFound, "job %d not found", request.GetJobID())
+	}
+	if worker := jobWorker(job); worker != request.GetWorker() {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %d is assigned to %s, not %s", job.ID, worker, request.GetWorker())
+	}
+	if attempt := jobAttempt(job); int(request.GetAttempt()) != attempt {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %d is on attempt %d, not %d", job.ID, attempt, request.GetAttempt())
+	}
+	if DebugLog {
+		fmt.Printf("Job %d is %v on %s\n", job.ID, common.Status(request.GetStatus()), request.GetWorker())
+	}
+
+	// the RUNNING report is best effort, so a finished job may still be STARTING
+	TransitionJobAt(job, common.STARTING, common.RUNNING, time.Unix(0, request.GetStartTime()))
+
+	stat := common.Status(request.GetStatus())
+	if stat.Finished() {
+		if DebugLog {
+			fmt.Printf("Job %d exited with %d\n", job.ID, request.GetExitCode())
+			fmt.Printf("Job %d output:\n%v\n", job.ID, request.GetOutput())
+			fmt.Printf("Job %d stderr:\n%v\n", job.ID, request.GetStderr())
+		}
+		// we make our own mind up about whether the process succeeded, but
+		// only the worker knows if it stopped the job itself
+		outcome := stat
+		if stat == common.SUCCESS || stat == common.FAILED {
+			outcome = common.Outcome(int(request.GetExitCode()), request.GetSignal(), request.GetError())
+		}
+		FinishJob(job, common.Attempt{
+			Status:   outcome,
+			ExitCode: int(request.GetExitCode()),
+			Signal:   request.GetSignal(),
+			Error:    request.GetError(),
+			Start:    time.Unix(0, request.GetStartTime()),
+			End:      time.Unix(0, request.GetTime()),
+		})
+	}
+
+	response := &pbMessages.JobStatusAck{
+		JobID: job.ID,
 	}
 	return response, nil
 }
 
-// Start the HelloRequest listener
+// Start the HelloRequest listener, which also serves the JobService,
+// ClusterService, JobStatusService, OutputService, SessionService and
+// LeaseService
 func StartHelloListener(wg *sync.WaitGroup) {
 	address := "0.0.0.0:50050"
 	lis, err := net.Listen("tcp", address)
@@ -52,8 +151,15 @@ func StartHelloListener(wg *sync.WaitGroup) {
 	}
 	fmt.Printf("Herd commander is listening for HelloRequest on %v ...\n", address)
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(grpc.KeepaliveEnforcementPolicy(common.ServerKeepalive))
 	pbMessages.RegisterHelloServiceServer(s, &commander{})
+	pbMessages.RegisterJobServiceServer(s, &commander{})
+	pbMessages.RegisterClusterServiceServer(s, &commander{})
+	pbMessages.RegisterJobStatusServiceServer(s, &commander{})
+	pbMessages.RegisterOutputServiceServer(s, &commander{})
+	pbMessages.RegisterSessionServiceServer(s, &commander{})
+	pbMessages.RegisterLeaseServiceServer(s, &commander{})
+	pbMessages.RegisterScheduleServiceServer(s, &commander{})
 
 	s.Serve(lis)
 }
@@ -61,14 +167,21 @@ func StartHelloListener(wg *sync.WaitGroup) {
 // RunHearbeat responsible for sending ping (hearbeat) messages to workers
 func RunHearbeat(wg *sync.WaitGroup) {
 	for true {
-		for host, _ := range Workers {
+		for _, host := range Workers.Hosts() {
+			if Workers.IsPull(host) {
+				// nothing to send to, they keep in touch by leasing work
+				if Workers.GetStatus(host) == WORKER_ONLINE && time.Since(Workers.LastSeen(host)) > leaseDuration {
+					fmt.Printf("Setting %s to OFFLINE\n", host)
+					Workers.SetStatus(host, WORKER_OFFLINE)
+				}
+				continue
+			}
 			if Workers.GetStatus(host) == WORKER_ONLINE {
 				sent := false
 				retry := 0
 				for retry < 5 {
-					connStr := fmt.Sprintf("%s:50051", host)
 					pMessage := &pbMessages.Ping{Name: "name 1"}
-					sent = SendHeartbeatMessage(connStr, pMessage)
+					sent = SendHeartbeatMessage(host, pMessage)
 					if sent {
 						retry = 5
 					} else {
@@ -92,9 +205,8 @@ func RunHearbeat(wg *sync.WaitGroup) {
 			}
 			if Workers.GetStatus(host) == WORKER_OFFLINE {
 				sent := false
-				connStr := fmt.Sprintf("%s:50051", host)
 				pMessage := &pbMessages.Ping{Name: "name 1"}
-				sent = SendHeartbeatMessage(connStr, pMessage)
+				sent = SendHeartbeatMessage(host, pMessage)
 				if sent {
 					fmt.Printf("Setting %s to ONLINE\n", host)
 					Workers.SetStatus(host, WORKER_ONLINE)
@@ -106,19 +218,18 @@ func RunHearbeat(wg *sync.WaitGroup) {
 	}
 }
 
-func SendHeartbeatMessage(connString string, message *pbMessages.Ping) bool {
-	opts := grpc.WithInsecure()
-	cc, err := grpc.Dial(connString, opts)
+func SendHeartbeatMessage(host string, message *pbMessages.Ping) bool {
+	networkclient, err := Workers.HeartbeatClient(host)
 	if err != nil {
 		if DebugLog {
 			log.Printf("gRPC dial error: %v\n", err)
 		}
 		return false
 	}
-	defer cc.Close()
 
-	networkclient := pbMessages.NewHeartbeatServiceClient(cc)
-	response, err := networkclient.Heartbeat(context.Background(), message)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	response, err := networkclient.Heartbeat(ctx, message)
 	if err != nil {
 		if DebugLog {
 			log.Printf("SendHeartbeatMessage() failed: %v\n", err)
@@ -126,93 +237,281 @@ func SendHeartbeatMessage(connString string, message *pbMessages.Ping) bool {
 		return false
 	} else {
 		if response != nil {
+			if response.GetResources() != nil {
+				Workers.SetCapacity(host, response.GetResources())
+			}
 			if DebugLog {
 				fmt.Printf("Sent 'Ping' to 'Heartbeat' service, received 'Pong'\n")
 			}
 		}
 	}
-	cc.Close()
 	return true
 }
 
+// reclaimJobs takes back the jobs sent to workers in push or session mode
+// which have been lost, so they are retried according to their policy
+// instead of waiting for ever on a worker which may never report them.
+// Workers in pull mode lose their jobs when their leases expire instead.
+func reclaimJobs() {
+	CommandsMtx.Lock()
+	hosts := make(map[string]bool)
+	for _, job := range Commands {
+		if (job.Status == common.STARTING || job.Status == common.RUNNING) && job.LeaseEnd.IsZero() && job.Worker != "" {
+			hosts[job.Worker] = false
+		}
+	}
+	CommandsMtx.Unlock()
+	if len(hosts) == 0 {
+		return
+	}
+	lost := false
+	for host := range hosts {
+		hosts[host] = Workers.Lost(host)
+		lost = lost || hosts[host]
+	}
+	if !lost {
+		return
+	}
+
+	now := time.Now()
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	for _, job := range Commands {
+		if (job.Status != common.STARTING && job.Status != common.RUNNING) || !job.LeaseEnd.IsZero() || !hosts[job.Worker] {
+			continue
+		}
+		log.Printf("Taking job %d back from %s, which has been lost\n", job.ID, job.Worker)
+		takeBackJob(job, fmt.Sprintf("worker %s was lost", job.Worker), now)
+	}
+}
+
 // RunWorkSender is responsible for sending out work units to workers
 func RunWorkSender(wg *sync.WaitGroup) {
 	for true {
-		if len(Commands) > 0 {
-			for host, _ := range Workers {
-				// For each host we know about
-				if Workers.GetNetErrors(host) > 10 {
-					continue
-				}
-				// if node is online, try and send
-				if Workers.GetStatus(host) == WORKER_ONLINE {
-					sent := false
-					retry := 0
-					for retry < 5 {
-						connStr := fmt.Sprintf("%s:50052", host)
-						// construct the job struct
-						var job common.Job
-						job.Command = "ls"
-						job.Args = append(job.Args, "-l")
-						job.Status = common.WAITING
-
-						// serialise the struct into buffer
-						var buffer bytes.Buffer
-						enc := gob.NewEncoder(&buffer)
-						err := enc.Encode(job)
-						if err != nil {
-							log.Println("encode error:", err)
-						}
-
-						// turn buffer into []byte for protocol buffers message
-						jobdata := buffer.Bytes()
-
-						//construct the message and send
-						pMessage := &pbMessages.WorkRequest{JobID: 1, Job: jobdata}
-						sent = SendWorkMessage(connStr, pMessage)
-						if sent {
-							retry = 5
-						} else {
-							Workers.AddNetError(host)
-							time.Sleep(1 * time.Second)
-						}
-						retry += 1
-					}
-				}
+		expireLeases()
+		reclaimJobs()
+		failOrphans()
+		releaseBlocked()
+		checkSchedulable()
+		reserved := reservations()
+		for _, job := range WaitingJobs() {
+			if job.FanOut() {
+				fanOutJob(job, reserved)
+				continue
+			}
+			dispatchJob(job, reserved)
+			if jobStatus(job) == common.WAITING && len(candidateWorkers(job, reserved)) == 0 {
+				// every worker it could run on is full up
+				preemptFor(job, reserved)
+			}
+		}
+		select {
+		case <-workReady:
+		case <-time.After(5 * time.Second):
+		}
+	}
+
+}
+
+// wakeWorkSender starts RunWorkSender's next pass straight away, for jobs
+// which have just become ready to be sent out
+func wakeWorkSender() {
+	select {
+	case workReady <- struct{}{}:
+	default:
+	}
+}
+
+// dispatchJob assigns a job to the first online worker which accepts it,
+// adding it to the reservation on that worker. If no worker can be reached the
+// job is left WAITING for the next pass.
+func dispatchJob(job *common.Job, reserved map[string]reservation) {
+	spec := job.Spec()
+	attempt := jobAttempt(job)
+
+	for _, host := range candidateWorkers(job, reserved) {
+		// the job may have been cancelled in the meantime
+		if !AssignJob(job, host) {
+			return
+		}
+		if Workers.ProtocolVersion(host) == common.PROTOCOL_V1 {
+			// the worker only replies once the job has finished
+			reserve(reserved, host, job)
+			go sendGobJob(job, host)
+			return
+		}
+
+		var response *pbMessages.WorkResponse
+		var err error
+		retry := 0
+		for retry < 5 {
+			//construct the message and send
+			pMessage := &pbMessages.WorkRequest{JobID: job.ID, Spec: spec, Attempt: int32(attempt)}
+			response, err = SendWorkMessage(host, pMessage)
+			if err == nil || !isNetworkError(err) {
+				retry = 5
+			} else {
+				Workers.AddNetError(host)
+				time.Sleep(1 * time.Second)
+			}
+			retry += 1
+		}
+
+		if err != nil && isNetworkError(err) {
+			// the job may have reached the worker even so, and must not run
+			// there as well as on the next worker
+			if !retractJob(job, host) {
+				// left with the worker until it reports the job or is lost
+				reserve(reserved, host, job)
+				return
 			}
-			time.Sleep(10 * time.Second)
+			TransitionJob(job, common.STARTING, common.WAITING)
+			continue
+		}
+		if status.Code(err) == codes.ResourceExhausted {
+			// the worker is full up, back off from it and try the next one
+			if DebugLog {
+				fmt.Printf("Job %d turned away by %s: %v\n", job.ID, host, err)
+			}
+			Workers.SetBusy(host, busyBackoff)
+			TransitionJob(job, common.STARTING, common.WAITING)
+			continue
+		}
+		if err != nil {
+			log.Printf("Job %d rejected by %s: %v\n", job.ID, host, err)
+			TransitionJob(job, common.STARTING, common.FAILED)
+			return
+		}
+
+		// the worker reports back through ReportStatus from here on
+		if DebugLog {
+			fmt.Printf("Job %d accepted by %s\n", response.JobID, host)
+		}
+		reserve(reserved, host, job)
+		// a cancel which arrived while the job was on its way
+		if cancelRequested(job) {
+			go forwardCancel(job, host)
+		}
+		return
+	}
+}
+
+// gobJob is a job as sent to PROTOCOL_V1 workers, which decode it into their
+// own common.Job by field name
+type gobJob struct {
+	Command string
+	Args    []string
+}
+
+// sendGobJob sends a job gob encoded to a PROTOCOL_V1 worker, which runs it
+// before replying with its results, and finishes the job from the reply. The
+// job counts as RUNNING from when it is sent.
+func sendGobJob(job *common.Job, host string) {
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(gobJob{Command: job.Command, Args: job.Args})
+	if err != nil {
+		log.Println("encode error:", err)
+		TransitionJob(job, common.STARTING, common.FAILED)
+		return
+	}
+	start := time.Now()
+	TransitionJobAt(job, common.STARTING, common.RUNNING, start)
+
+	pMessage := &pbMessages.WorkRequest{JobID: job.ID, Job: buffer.Bytes()}
+	response, err := SendWorkMessage(host, pMessage)
+	if err != nil {
+		if isNetworkError(err) {
+			Workers.AddNetError(host)
+		}
+		log.Printf("Job %d failed on %s: %v\n", job.ID, host, err)
+		FinishJob(job, common.Attempt{Status: common.FAILED, ExitCode: -1, Error: err.Error(), Start: start, End: time.Now()})
+		return
+	}
+	if DebugLog {
+		fmt.Printf("Job %d exited with %d\n", job.ID, response.GetExitCode())
+		fmt.Printf("Job %d output:\n%v\n", job.ID, response.GetOutput())
+	}
+	attempt := common.Attempt{
+		Status:   common.Outcome(int(response.GetExitCode()), response.GetSignal(), response.GetError()),
+		ExitCode: int(response.GetExitCode()),
+		Signal:   response.GetSignal(),
+		Error:    response.GetError(),
+		Start:    start,
+		End:      time.Now(),
+	}
+	// workers which report their own times
+	if response.GetStartTime() != 0 {
+		attempt.Start = time.Unix(0, response.GetStartTime())
+		attempt.End = time.Unix(0, response.GetEndTime())
+	}
+	FinishJob(job, attempt)
+}
+
+// candidateWorkers returns the online workers matching a job's selector with
+// room for it which it could be sent to, best fit first, leaving out workers
+// in pull mode which lease their own work and those backed off from. A job
+// which asks to be retried elsewhere only goes back to a worker it failed on
+// if there is nowhere else to go.
+func candidateWorkers(job *common.Job, reserved map[string]reservation) []string {
+	var online []string
+	for _, host := range Workers.Hosts() {
+		// For each host we know about
+		if Workers.GetNetErrors(host) > 10 || Workers.IsPull(host) || Workers.IsBusy(host) {
+			continue
+		}
+		if !suits(job, host) || !fits(job, host, reserved) {
+			continue
+		}
+		// if node is online, try and send
+		if Workers.GetStatus(host) == WORKER_ONLINE {
+			online = append(online, host)
 		}
-		time.Sleep(5 * time.Second)
+	}
+	bestFit(job, online, reserved)
+	if !job.Retry.DifferentWorker {
+		return online
 	}
 
+	failed := failedWorkers(job)
+	var fresh, tried []string
+	for _, host := range online {
+		if failed[host] {
+			tried = append(tried, host)
+		} else {
+			fresh = append(fresh, host)
+		}
+	}
+	return append(fresh, tried...)
 }
 
-func SendWorkMessage(connString string, message *pbMessages.WorkRequest) bool {
-	opts := grpc.WithInsecure()
-	cc, err := grpc.Dial(connString, opts)
+// isNetworkError reports whether a gRPC error means the message never reached
+// the worker, as opposed to the worker refusing it
+func isNetworkError(err error) bool {
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
+
+func SendWorkMessage(host string, message *pbMessages.WorkRequest) (*pbMessages.WorkResponse, error) {
+	networkclient, err := Workers.WorkClient(host)
 	if err != nil {
 		if DebugLog {
 			log.Printf("gRPC dial error: %v\n", err)
 		}
-		return false
+		return nil, err
 	}
-	defer cc.Close()
 
-	networkclient := pbMessages.NewWorkServiceClient(cc)
 	response, err := networkclient.Work(context.Background(), message)
 	if err != nil {
 		if DebugLog {
 			log.Printf("SendWorkMessage() failed: %v\n", err)
 		}
-		return false
+		return nil, err
 	} else {
 		if response != nil {
 			if DebugLog {
 				fmt.Printf("Sent 'WorkRequest' to 'Work' service, received 'WorkResponse'\n")
-				fmt.Printf("WorkResponse.Output:\n%v\n", response.Output)
 			}
 		}
 	}
-	cc.Close()
-	return true
+	return response, nil
 }
@@ -0,0 +1,189 @@
+package commander
+
+import (
+	"common"
+	"context"
+	"math"
+	"pbMessages"
+	"sort"
+	"sync"
+	"time"
+)
+
+// The tenant jobs submitted without one are run for
+const DEFAULT_TENANT = "default"
+
+var (
+	// TenantWeights are the shares of worker slots tenants are due relative
+	// to each other, 1 for any tenant not listed
+	TenantWeights = make(map[string]float64)
+	// UsageHalfLife is how long it takes for slot usage to count for half
+	// as much towards a tenant's share
+	UsageHalfLife = time.Hour
+
+	// decayed slot seconds used by each tenant's finished runs, only kept in
+	// memory as it fades away anyway
+	tenantUsage = make(map[string]float64)
+	lastDecayed time.Time
+	usageMtx    sync.Mutex
+)
+
+func tenantWeight(tenant string) float64 {
+	weight, found := TenantWeights[tenant]
+	if !found {
+		return 1
+	}
+	return weight
+}
+
+// runningByTenant counts the jobs taking up a worker slot for each tenant,
+// CommandsMtx must be held
+func runningByTenant() map[string]int {
+	running := make(map[string]int)
+	for _, job := range Commands {
+		if job.Worker != "" && (job.Status == common.STARTING || job.Status == common.RUNNING) {
+			running[job.Tenant] += 1
+		}
+	}
+	return running
+}
+
+// decayUsage brings every tenant's usage up to date, usageMtx must be held
+func decayUsage(now time.Time) {
+	if !lastDecayed.IsZero() {
+		decay := math.Pow(0.5, now.Sub(lastDecayed).Seconds()/UsageHalfLife.Seconds())
+		for tenant := range tenantUsage {
+			tenantUsage[tenant] *= decay
+		}
+	}
+	lastDecayed = now
+}
+
+// chargeUsage adds the time a run of one of a tenant's jobs took up a worker
+// slot for to the tenant's usage. Jobs still running count through the slots
+// they hold instead.
+func chargeUsage(tenant string, attempt common.Attempt) {
+	if attempt.Start.IsZero() || !attempt.End.After(attempt.Start) {
+		return
+	}
+	usageMtx.Lock()
+	defer usageMtx.Unlock()
+	decayUsage(time.Now())
+	tenantUsage[tenant] += attempt.End.Sub(attempt.Start).Seconds()
+}
+
+// fairShare orders WAITING jobs highest priority first, sharing out the
+// worker slots between tenants in proportion to their weights within each
+// priority. The tenant furthest below its share goes next each time, going by
+// the slots it has in use and those it has used recently, and its jobs go in
+// the order they were submitted. CommandsMtx must be held.
+func fairShare(waiting []*common.Job) {
+	running := runningByTenant()
+	usageMtx.Lock()
+	decayUsage(time.Now())
+	load := make(map[string]float64)
+	for _, job := range waiting {
+		// recent usage as an average number of slots, to add to those in use
+		load[job.Tenant] = float64(running[job.Tenant]) + tenantUsage[job.Tenant]/UsageHalfLife.Seconds()
+	}
+	usageMtx.Unlock()
+
+	byPriority(waiting)
+	for start := 0; start < len(waiting); {
+		end := start
+		for end < len(waiting) && waiting[end].Priority == waiting[start].Priority {
+			end++
+		}
+		interleave(waiting[start:end], load)
+		start = end
+	}
+}
+
+// interleave reorders jobs of the same priority, giving the next place to the
+// tenant with the least load for its weight and counting each job placed
+// towards its tenant's load
+func interleave(jobs []*common.Job, load map[string]float64) {
+	queues := make(map[string][]*common.Job)
+	var tenants []string
+	for _, job := range jobs {
+		if _, found := queues[job.Tenant]; !found {
+			tenants = append(tenants, job.Tenant)
+		}
+		queues[job.Tenant] = append(queues[job.Tenant], job)
+	}
+	if len(tenants) < 2 {
+		return
+	}
+
+	for i := range jobs {
+		next := ""
+		for _, tenant := range tenants {
+			if len(queues[tenant]) == 0 {
+				continue
+			}
+			if next == "" || load[tenant]/tenantWeight(tenant) < load[next]/tenantWeight(next) {
+				next = tenant
+			}
+		}
+		jobs[i] = queues[next][0]
+		queues[next] = queues[next][1:]
+		load[next] += 1
+	}
+}
+
+// This function implements the ListTenants interface, showing each tenant
+// with jobs running or waiting, or recent usage, against its fair share
+func (*commander) ListTenants(ctx context.Context, request *pbMessages.ListTenantsRequest) (*pbMessages.ListTenantsResponse, error) {
+	tenants := make(map[string]*pbMessages.TenantInfo)
+	tenant := func(name string) *pbMessages.TenantInfo {
+		info, found := tenants[name]
+		if !found {
+			info = &pbMessages.TenantInfo{Name: name, Weight: tenantWeight(name)}
+			tenants[name] = info
+		}
+		return info
+	}
+
+	CommandsMtx.Lock()
+	for name, slots := range runningByTenant() {
+		tenant(name).Running = int32(slots)
+	}
+	for _, job := range Commands {
+		if (job.Status == common.WAITING || job.Status == common.UNSCHEDULABLE) && !job.FanOut() {
+			tenant(job.Tenant).Waiting += 1
+		}
+	}
+	CommandsMtx.Unlock()
+
+	usageMtx.Lock()
+	decayUsage(time.Now())
+	for name, usage := range tenantUsage {
+		if usage >= 1 {
+			tenant(name).Usage = usage
+		}
+	}
+	usageMtx.Unlock()
+
+	var inUse int32
+	var activeWeight float64
+	for _, info := range tenants {
+		inUse += info.Running
+		if info.Running > 0 || info.Waiting > 0 {
+			activeWeight += info.Weight
+		}
+	}
+	response := &pbMessages.ListTenantsResponse{}
+	for _, info := range tenants {
+		if inUse > 0 {
+			info.Share = float64(info.Running) / float64(inUse)
+		}
+		if activeWeight > 0 && (info.Running > 0 || info.Waiting > 0) {
+			info.FairShare = info.Weight / activeWeight
+		}
+		response.Tenants = append(response.Tenants, info)
+	}
+	sort.Slice(response.Tenants, func(i, j int) bool {
+		return response.Tenants[i].Name < response.Tenants[j].Name
+	})
+	return response, nil
+}
@@ -0,0 +1,50 @@
+package commander
+
+import (
+	"common"
+	"reflect"
+	"testing"
+)
+
+func TestInterleave(t *testing.T) {
+	defer func(weights map[string]float64) { TenantWeights = weights }(TenantWeights)
+	TenantWeights = map[string]float64{"big": 2}
+
+	tests := []struct {
+		name    string
+		tenants []string // of the jobs, in submission order
+		load    map[string]float64
+		want    []string
+	}{
+		{"one tenant", []string{"a", "a", "a"}, nil, []string{"a", "a", "a"}},
+		{"taking turns", []string{"a", "a", "a", "b", "b", "b"}, nil, []string{"a", "b", "a", "b", "a", "b"}},
+		{"least loaded first", []string{"a", "a", "b", "b"}, map[string]float64{"a": 1}, []string{"b", "a", "b", "a"}},
+		{"catching up", []string{"a", "a", "b", "b", "b"}, map[string]float64{"a": 2}, []string{"b", "b", "a", "b", "a"}},
+		{"by weight", []string{"a", "a", "big", "big", "big", "big"}, nil, []string{"a", "big", "big", "a", "big", "big"}},
+	}
+	for _, test := range tests {
+		var jobs []*common.Job
+		for i, tenant := range test.tenants {
+			jobs = append(jobs, &common.Job{ID: int32(i + 1), Tenant: tenant})
+		}
+		load := make(map[string]float64)
+		for tenant, l := range test.load {
+			load[tenant] = l
+		}
+		interleave(jobs, load)
+
+		var got []string
+		last := make(map[string]int32)
+		for _, job := range jobs {
+			got = append(got, job.Tenant)
+			// each tenant's own jobs stay in order
+			if job.ID < last[job.Tenant] {
+				t.Errorf("%s: job %d placed after job %d", test.name, job.ID, last[job.Tenant])
+			}
+			last[job.Tenant] = job.ID
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: ordered %v, want %v", test.name, got, test.want)
+		}
+	}
+}
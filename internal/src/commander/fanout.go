@@ -0,0 +1,192 @@
+package commander
+
+import (
+	"common"
+	"fmt"
+	"log"
+	"pbMessages"
+	"time"
+)
+
+// fanOutWorkers returns the online workers a job dispatched to more than one
+// worker should run on, or nil if there are not enough of them yet. Workers
+// with room for the job come first, best fit first.
+func fanOutWorkers(job *common.Job, reserved map[string]reservation) []string {
+	var free, busy []string
+	for _, host := range Workers.Hosts() {
+		if Workers.GetNetErrors(host) > 10 || Workers.GetStatus(host) != WORKER_ONLINE || !suits(job, host) {
+			continue
+		}
+		if fits(job, host, reserved) {
+			free = append(free, host)
+		} else {
+			busy = append(busy, host)
+		}
+	}
+	bestFit(job, free, reserved)
+	hosts := append(free, busy...)
+
+	if job.Dispatch == common.DISPATCH_ALL {
+		return hosts
+	}
+	if len(hosts) < job.Count {
+		return nil
+	}
+	return hosts[:job.Count]
+}
+
+// fanOutJob dispatches a job to more than one worker, by adding a child job
+// pinned to each of them and marking the job itself STARTING. The children
+// are sent out like any other job and the job follows how they get on.
+func fanOutJob(job *common.Job, reserved map[string]reservation) {
+	hosts := fanOutWorkers(job, reserved)
+	if len(hosts) == 0 {
+		// not enough workers yet, checkSchedulable says if there never will be
+		return
+	}
+
+	CommandsMtx.Lock()
+	if job.Status != common.WAITING {
+		CommandsMtx.Unlock()
+		return
+	}
+	var children []*common.Job
+	for _, host := range hosts {
+		child := addJob(&common.Job{
+			Command:       job.Command,
+			Args:          job.Args,
+			Env:           job.Env,
+			WorkingDir:    job.WorkingDir,
+			Timeout:       job.Timeout,
+			KillGrace:     job.KillGrace,
+			Labels:        job.Labels,
+			Selector:      job.Selector,
+			Resources:     job.Resources,
+			Retry:         job.Retry,
+			Priority:      job.Priority,
+			PriorityClass: job.PriorityClass,
+			Tenant:        job.Tenant,
+			Parent:        job.ID,
+			Pin:           host,
+		})
+		job.Children = append(job.Children, child.ID)
+		children = append(children, child)
+	}
+	job.SetStatus(common.STARTING, time.Now())
+	saveJob(job)
+	CommandsMtx.Unlock()
+
+	fmt.Printf("Job %d fanned out to %d workers\n", job.ID, len(children))
+	for _, child := range children {
+		dispatchJob(child, reserved)
+	}
+}
+
+// updateParent brings the status of the job a child job is a run of up to
+// date, CommandsMtx must be held. The job is RUNNING once any of its children
+// is, and once they have all finished it is SUCCESS if they all succeeded,
+// FAILED if any of them failed and CANCELLED otherwise.
+func updateParent(child *common.Job) {
+	if child.Parent == 0 {
+		return
+	}
+	job := findJob(child.Parent)
+	if job == nil || job.Status.Finished() {
+		return
+	}
+
+	counts := fanOutCounts(job)
+	now := time.Now()
+	started := counts.GetRunning()+counts.GetSucceeded()+counts.GetFailed() > 0
+	if job.Status == common.STARTING && started {
+		job.SetStatus(common.RUNNING, now)
+	}
+	if counts.GetPending() == 0 && counts.GetRunning() == 0 {
+		to := common.CANCELLED
+		if counts.GetSucceeded() == counts.GetTotal() {
+			to = common.SUCCESS
+		} else if counts.GetFailed() > 0 {
+			to = common.FAILED
+		}
+		job.SetStatus(to, now)
+		fmt.Printf("Job %d finished on %d workers: %d succeeded, %d failed, %d cancelled\n",
+			job.ID, counts.GetTotal(), counts.GetSucceeded(), counts.GetFailed(), counts.GetCancelled())
+	}
+	saveJob(job)
+}
+
+// failOrphans fails the jobs pinned to a worker which has been removed or lost
+// before they could be sent to it, as they would otherwise wait for it for
+// ever and keep the job they were fanned out from from finishing
+func failOrphans() {
+	CommandsMtx.Lock()
+	hosts := make(map[string]bool)
+	for _, job := range Commands {
+		if job.Pin != "" && (job.Status == common.WAITING || job.Status == common.UNSCHEDULABLE) {
+			hosts[job.Pin] = false
+		}
+	}
+	CommandsMtx.Unlock()
+	lost := false
+	for host := range hosts {
+		hosts[host] = Workers.Lost(host)
+		lost = lost || hosts[host]
+	}
+	if !lost {
+		return
+	}
+
+	now := time.Now()
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	for _, job := range Commands {
+		if (job.Status != common.WAITING && job.Status != common.UNSCHEDULABLE) || !hosts[job.Pin] {
+			continue
+		}
+		job.Error = fmt.Sprintf("worker %s was lost", job.Pin)
+		err := job.SetStatusFor(common.FAILED, now, job.Error)
+		if err != nil {
+			log.Printf("ERROR: %v\n", err)
+			continue
+		}
+		fmt.Printf("Job %d FAILED, %s\n", job.ID, job.Error)
+		saveJob(job)
+		updateParent(job)
+	}
+}
+
+// fanOutCounts adds up how the children of a job are getting on, with the
+// result of each, CommandsMtx must be held
+func fanOutCounts(job *common.Job) *pbMessages.FanOutStatus {
+	counts := &pbMessages.FanOutStatus{}
+	for _, id := range job.Children {
+		child := findJob(id)
+		if child == nil {
+			continue
+		}
+		counts.Total += 1
+		switch child.Status {
+		case common.RUNNING:
+			counts.Running += 1
+		case common.SUCCESS:
+			counts.Succeeded += 1
+		case common.CANCELLED:
+			counts.Cancelled += 1
+		default:
+			// failed, timed out, expired or skipped
+			if child.Status.Finished() {
+				counts.Failed += 1
+			} else {
+				counts.Pending += 1
+			}
+		}
+		counts.Results = append(counts.Results, &pbMessages.FanOutResult{
+			JobID:    child.ID,
+			Worker:   child.Pin,
+			Status:   int32(child.Status),
+			ExitCode: int32(child.ExitCode),
+			Error:    child.Error,
+		})
+	}
+	return counts
+}
@@ -0,0 +1,41 @@
+package commander
+
+import (
+	"common"
+	"testing"
+)
+
+func TestFanOutCounts(t *testing.T) {
+	defer func(jobs []*common.Job) { Commands = jobs }(Commands)
+
+	tests := []struct {
+		name     string
+		children []common.Status
+		want     [5]int32 // pending, running, succeeded, failed, cancelled
+	}{
+		{"not started", []common.Status{common.WAITING, common.STARTING, common.UNSCHEDULABLE}, [5]int32{3, 0, 0, 0, 0}},
+		{"running", []common.Status{common.RUNNING, common.WAITING}, [5]int32{1, 1, 0, 0, 0}},
+		{"all succeeded", []common.Status{common.SUCCESS, common.SUCCESS}, [5]int32{0, 0, 2, 0, 0}},
+		{"failed", []common.Status{common.FAILED, common.TIMED_OUT, common.SUCCESS}, [5]int32{0, 0, 1, 2, 0}},
+		{"expired and skipped", []common.Status{common.EXPIRED, common.SKIPPED}, [5]int32{0, 0, 0, 2, 0}},
+		{"cancelled", []common.Status{common.CANCELLED, common.SUCCESS}, [5]int32{0, 0, 1, 0, 1}},
+	}
+	for _, test := range tests {
+		parent := &common.Job{ID: 1, Status: common.RUNNING}
+		Commands = []*common.Job{parent}
+		for i, stat := range test.children {
+			child := &common.Job{ID: int32(i + 2), Status: stat, Parent: parent.ID}
+			parent.Children = append(parent.Children, child.ID)
+			Commands = append(Commands, child)
+		}
+
+		counts := fanOutCounts(parent)
+		got := [5]int32{counts.GetPending(), counts.GetRunning(), counts.GetSucceeded(), counts.GetFailed(), counts.GetCancelled()}
+		if got != test.want {
+			t.Errorf("%s: counted %v, want %v", test.name, got, test.want)
+		}
+		if counts.GetTotal() != int32(len(test.children)) {
+			t.Errorf("%s: %d in total, want %d", test.name, counts.GetTotal(), len(test.children))
+		}
+	}
+}
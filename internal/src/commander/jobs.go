@@ -0,0 +1,440 @@
+package commander
+
+import (
+	"common"
+	"context"
+	"fmt"
+	"log"
+	"pbMessages"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddJob gives a new job an ID and appends it to Commands as WAITING, as
+// BLOCKED if it depends on other jobs or as DELAYED if it is not due yet
+func AddJob(job *common.Job) *common.Job {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	return addJob(job)
+}
+
+// addJob is AddJob for callers which hold CommandsMtx
+func addJob(job *common.Job) *common.Job {
+	lastJobID += 1
+	job.ID = lastJobID
+	job.Status = common.WAITING
+	if len(job.DependsOn) > 0 {
+		job.Status = common.BLOCKED
+	} else if job.RunAt.After(time.Now()) {
+		job.Status = common.DELAYED
+	}
+	job.History = append(job.History, common.Transition{Status: job.Status, Time: time.Now()})
+	Commands = append(Commands, job)
+	saveJob(job)
+	addTimers(job)
+	return job
+}
+
+// GetJob returns the job with the given ID, or nil if there is no such job
+func GetJob(jobID int32) *common.Job {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	return findJob(jobID)
+}
+
+// findJob is GetJob for callers which hold CommandsMtx
+func findJob(jobID int32) *common.Job {
+	for _, job := range Commands {
+		if job.ID == jobID {
+			return job
+		}
+	}
+	return nil
+}
+
+// WaitingJobs returns the jobs in Commands which are ready to be sent out,
+// highest priority first and shared fairly between tenants
+func WaitingJobs() []*common.Job {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	now := time.Now()
+	var waiting []*common.Job
+	for _, job := range Commands {
+		if job.Status == common.WAITING && !job.NotBefore.After(now) {
+			waiting = append(waiting, job)
+		}
+	}
+	fairShare(waiting)
+	return waiting
+}
+
+// checkSchedulable marks WAITING jobs UNSCHEDULABLE while there are not
+// enough online workers which match their selector, are big enough for them
+// and are the worker they are pinned to, if any. UNSCHEDULABLE jobs go back to
+// WAITING once there are.
+func checkSchedulable() {
+	CommandsMtx.Lock()
+	var jobs []*common.Job
+	for _, job := range Commands {
+		constrained := len(job.Selector) > 0 || job.Resources != (common.Resources{}) || job.Pin != "" || job.FanOut()
+		if constrained && (job.Status == common.WAITING || job.Status == common.UNSCHEDULABLE) {
+			jobs = append(jobs, job)
+		}
+	}
+	CommandsMtx.Unlock()
+
+	hosts := Workers.Hosts()
+	for _, job := range jobs {
+		needed := 1
+		if job.Dispatch == common.DISPATCH_N {
+			needed = job.Count
+		}
+		matched := 0
+		for _, host := range hosts {
+			if Workers.GetStatus(host) == WORKER_ONLINE && suits(job, host) {
+				matched += 1
+			}
+		}
+		if matched >= needed && TransitionJob(job, common.UNSCHEDULABLE, common.WAITING) {
+			fmt.Printf("Job %d can be scheduled again\n", job.ID)
+		}
+		if matched < needed && TransitionJob(job, common.WAITING, common.UNSCHEDULABLE) {
+			fmt.Printf("Job %d is unschedulable, %d online workers match %v with %v CPUs, %d bytes memory and %d bytes disk but it needs %d\n",
+				job.ID, matched, job.Selector, job.Resources.CPUs, job.Resources.Memory, job.Resources.Disk, needed)
+		}
+	}
+}
+
+// TransitionJob moves a job from one status to another, returning false if the
+// job was no longer in the expected status
+func TransitionJob(job *common.Job, from common.Status, to common.Status) bool {
+	return TransitionJobAt(job, from, to, time.Now())
+}
+
+// TransitionJobAt is TransitionJob for a transition which happened at a
+// known time, e.g. as reported by the worker
+func TransitionJobAt(job *common.Job, from common.Status, to common.Status, when time.Time) bool {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	if job.Status != from {
+		return false
+	}
+	err := job.SetStatus(to, when)
+	if err != nil {
+		log.Printf("ERROR: %v\n", err)
+		return false
+	}
+	saveJob(job)
+	updateParent(job)
+	return true
+}
+
+// AssignJob hands a WAITING job to a worker and marks it STARTING, returning
+// false if the job is no longer WAITING
+func AssignJob(job *common.Job, host string) bool {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	if job.Status != common.WAITING {
+		return false
+	}
+	job.SetStatus(common.STARTING, time.Now())
+	job.Worker = host
+	saveJob(job)
+	return true
+}
+
+// jobStatus returns the current status of a job
+func jobStatus(job *common.Job) common.Status {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	return job.Status
+}
+
+// FinishJob records how a RUNNING job's run ended. The job is marked with the
+// attempt's outcome, unless its retry policy sends it back to WAITING.
+func FinishJob(job *common.Job, attempt common.Attempt) {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	finishJob(job, attempt)
+}
+
+// finishJob is FinishJob for callers which hold CommandsMtx
+func finishJob(job *common.Job, attempt common.Attempt) {
+	if job.Status != common.RUNNING {
+		return
+	}
+	if job.PreemptedBy != 0 {
+		if attempt.Status == common.CANCELLED && !job.CancelRequested {
+			requeuePreempted(job, attempt)
+			return
+		}
+		// it finished before it could be stopped
+		job.PreemptedBy = 0
+	}
+	job.LeaseEnd = time.Time{}
+	chargeUsage(job.Tenant, attempt)
+	attempt.Number = job.Attempt()
+	attempt.Worker = job.Worker
+	job.Attempts = append(job.Attempts, attempt)
+	job.ExitCode = attempt.ExitCode
+	job.Signal = attempt.Signal
+	job.Error = attempt.Error
+
+	to := attempt.Status
+	tries := job.Tries()
+	if job.Retry.ShouldRetry(tries, attempt.Status, attempt.ExitCode) {
+		to = common.WAITING
+	}
+	err := job.SetStatus(to, attempt.End)
+	if err != nil {
+		log.Printf("ERROR: %v\n", err)
+		return
+	}
+	if to == common.WAITING {
+		job.Worker = ""
+		job.NotBefore = attempt.End.Add(job.Retry.Backoff(tries))
+		if DebugLog {
+			fmt.Printf("Job %d attempt %d was %v, retrying after %v\n", job.ID, attempt.Number, attempt.Status, job.NotBefore)
+		}
+	}
+	saveJob(job)
+	updateParent(job)
+}
+
+// failedWorkers returns the workers a job has already failed on
+func failedWorkers(job *common.Job) map[string]bool {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	failed := make(map[string]bool)
+	for _, attempt := range job.Attempts {
+		failed[attempt.Worker] = true
+	}
+	return failed
+}
+
+// jobAttempt returns the number of a job's current run
+func jobAttempt(job *common.Job) int {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	return job.Attempt()
+}
+
+// jobWorker returns the worker a job is assigned to
+func jobWorker(job *common.Job) string {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	return job.Worker
+}
+
+func jobInfo(job *common.Job) *pbMessages.JobInfo {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	info := &pbMessages.JobInfo{
+		JobID:     job.ID,
+		Command:   job.Command,
+		Args:      job.Args,
+		Status:    int32(job.Status),
+		Worker:    job.Worker,
+		ExitCode:  int32(job.ExitCode),
+		Signal:    job.Signal,
+		Error:     job.Error,
+		Timeout:   int64(job.Timeout),
+		KillGrace: int64(job.KillGrace),
+		Spec:      job.Spec(),
+		Retry: &pbMessages.RetryPolicy{
+			MaxAttempts:     int32(job.Retry.MaxAttempts),
+			BackoffBase:     int64(job.Retry.BackoffBase),
+			BackoffCap:      int64(job.Retry.BackoffCap),
+			Jitter:          job.Retry.Jitter,
+			DifferentWorker: job.Retry.DifferentWorker,
+		},
+		Priority:      int32(job.Priority),
+		PriorityClass: job.PriorityClass,
+		PreemptedBy:   job.PreemptedBy,
+		Tenant:        job.Tenant,
+		Dispatch:      pbMessages.DispatchMode(job.Dispatch),
+		Count:         int32(job.Count),
+		Parent:        job.Parent,
+		Workflow:      job.Workflow,
+		Name:          job.Name,
+		DependsOn:     job.DependsOn,
+		Schedule:      job.Schedule,
+		Ttl:           int64(job.TTL),
+	}
+	for _, code := range job.Retry.RetryableExitCodes {
+		info.Retry.RetryableExitCodes = append(info.Retry.RetryableExitCodes, int32(code))
+	}
+	if !job.NotBefore.IsZero() {
+		info.NotBefore = job.NotBefore.UnixNano()
+	}
+	if !job.LeaseEnd.IsZero() {
+		info.LeaseEnd = job.LeaseEnd.UnixNano()
+	}
+	if !job.RunAt.IsZero() {
+		info.RunAt = job.RunAt.UnixNano()
+	}
+	if expires := job.ExpiresAt(); !expires.IsZero() {
+		info.ExpiresAt = expires.UnixNano()
+	}
+	if job.FanOut() {
+		info.FanOut = fanOutCounts(job)
+	}
+	for _, attempt := range job.Attempts {
+		info.Attempts = append(info.Attempts, &pbMessages.JobAttempt{
+			Number:      int32(attempt.Number),
+			Worker:      attempt.Worker,
+			Status:      int32(attempt.Status),
+			ExitCode:    int32(attempt.ExitCode),
+			Signal:      attempt.Signal,
+			Error:       attempt.Error,
+			StartTime:   attempt.Start.UnixNano(),
+			EndTime:     attempt.End.UnixNano(),
+			PreemptedBy: attempt.PreemptedBy,
+		})
+	}
+	for _, transition := range job.History {
+		info.History = append(info.History, &pbMessages.JobTransition{
+			Status: int32(transition.Status),
+			Time:   transition.Time.UnixNano(),
+			Reason: transition.Reason,
+		})
+	}
+	return info
+}
+
+// validateJob checks a submitted job makes sense
+func validateJob(job *common.Job) error {
+	if job.Command == "" {
+		return status.Error(codes.InvalidArgument, "command must not be empty")
+	}
+	if job.Timeout < 0 || job.KillGrace < 0 {
+		return status.Error(codes.InvalidArgument, "timeout and kill grace must not be negative")
+	}
+	if job.Resources.CPUs < 0 || job.Resources.Memory < 0 || job.Resources.Disk < 0 {
+		return status.Error(codes.InvalidArgument, "resources must not be negative")
+	}
+	if job.Retry.Jitter < 0 || job.Retry.Jitter > 1 {
+		return status.Error(codes.InvalidArgument, "retry jitter must be between 0 and 1")
+	}
+	if job.Retry.BackoffBase < 0 || job.Retry.BackoffCap < 0 {
+		return status.Error(codes.InvalidArgument, "retry backoff must not be negative")
+	}
+	if job.Retry.MaxAttempts < 0 || job.Retry.MaxAttempts > common.MaxRetryAttempts {
+		return status.Errorf(codes.InvalidArgument, "retry max attempts must be between 0 and %d", common.MaxRetryAttempts)
+	}
+	for _, r := range job.Selector {
+		err := r.Validate()
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	switch job.Dispatch {
+	case common.DISPATCH_ONE, common.DISPATCH_ALL:
+		if job.Count != 0 {
+			return status.Errorf(codes.InvalidArgument, "a worker count only goes with dispatch to N workers, not %v", job.Dispatch)
+		}
+	case common.DISPATCH_N:
+		if job.Count < 1 {
+			return status.Error(codes.InvalidArgument, "dispatch to N workers needs a worker count of at least 1")
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown dispatch mode %d", job.Dispatch)
+	}
+	return nil
+}
+
+func retryPolicy(retry *pbMessages.RetryPolicy) common.RetryPolicy {
+	policy := common.RetryPolicy{
+		MaxAttempts:     int(retry.GetMaxAttempts()),
+		BackoffBase:     time.Duration(retry.GetBackoffBase()),
+		BackoffCap:      time.Duration(retry.GetBackoffCap()),
+		Jitter:          retry.GetJitter(),
+		DifferentWorker: retry.GetDifferentWorker(),
+	}
+	for _, code := range retry.GetRetryableExitCodes() {
+		policy.RetryableExitCodes = append(policy.RetryableExitCodes, int(code))
+	}
+	return policy
+}
+
+// jobFromRequest builds the job a submission asks for, checking it makes sense
+func jobFromRequest(request *pbMessages.SubmitJobRequest) (*common.Job, error) {
+	spec := request.GetSpec()
+	if spec == nil {
+		return nil, status.Error(codes.InvalidArgument, "a job needs a spec")
+	}
+	job := common.JobFromSpec(spec)
+	job.Retry = retryPolicy(request.GetRetry())
+	job.Dispatch = common.Dispatch(request.GetDispatch())
+	job.Count = int(request.GetCount())
+	priority, err := jobPriority(int(request.GetPriority()), request.GetPriorityClass())
+	if err != nil {
+		return nil, err
+	}
+	job.Priority = priority
+	job.PriorityClass = request.GetPriorityClass()
+	job.Tenant = request.GetTenant()
+	if job.Tenant == "" {
+		job.Tenant = DEFAULT_TENANT
+	}
+	if request.GetRunAt() != 0 && request.GetDelay() != 0 {
+		return nil, status.Error(codes.InvalidArgument, "a job takes a time to run at or a delay, not both")
+	}
+	if request.GetDelay() < 0 || request.GetTtl() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "delay and TTL must not be negative")
+	}
+	if request.GetRunAt() != 0 {
+		job.RunAt = time.Unix(0, request.GetRunAt())
+	} else if request.GetDelay() > 0 {
+		job.RunAt = time.Now().Add(time.Duration(request.GetDelay()))
+	}
+	job.TTL = time.Duration(request.GetTtl())
+	err = validateJob(job)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// This function implements the SubmitJob interface
+func (*commander) SubmitJob(ctx context.Context, request *pbMessages.SubmitJobRequest) (*pbMessages.SubmitJobResponse, error) {
+	job, err := jobFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	job = AddJob(job)
+	if DebugLog {
+		fmt.Printf("Queued job %d: %s %v\n", job.ID, job.Command, job.Args)
+	}
+
+	response := &pbMessages.SubmitJobResponse{
+		JobID: job.ID,
+	}
+	return response, nil
+}
+
+// This function implements the GetJob interface
+func (*commander) GetJob(ctx context.Context, request *pbMessages.GetJobRequest) (*pbMessages.JobInfo, error) {
+	job := GetJob(request.GetJobID())
+	if job == nil {
+		return nil, status.Errorf(codes.NotFound, "job %d not found", request.GetJobID())
+	}
+	return jobInfo(job), nil
+}
+
+// This function implements the ListJobs interface
+func (*commander) ListJobs(ctx context.Context, request *pbMessages.ListJobsRequest) (*pbMessages.ListJobsResponse, error) {
+	CommandsMtx.Lock()
+	jobs := make([]*common.Job, len(Commands))
+	copy(jobs, Commands)
+	CommandsMtx.Unlock()
+
+	response := &pbMessages.ListJobsResponse{}
+	for _, job := range jobs {
+		response.Jobs = append(response.Jobs, jobInfo(job))
+	}
+	return response, nil
+}
@@ -0,0 +1,184 @@
+package commander
+
+import (
+	"common"
+	"context"
+	"fmt"
+	"log"
+	"pbMessages"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// How long a worker in pull mode has to renew the lease on a job before the
+// job is taken back from it
+const leaseDuration = 30 * time.Second
+
+// leaseJob hands a WAITING job to a worker in pull mode until the lease ends,
+// returning false if the job is no longer WAITING
+func leaseJob(job *common.Job, host string, until time.Time) bool {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	if job.Status != common.WAITING {
+		return false
+	}
+	job.SetStatus(common.STARTING, time.Now())
+	job.Worker = host
+	job.LeaseEnd = until
+	saveJob(job)
+	return true
+}
+
+// renewLease extends the lease a worker holds on a job, returning false if
+// the worker no longer holds it
+func renewLease(job *common.Job, host string, attempt int, until time.Time) bool {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	if job.Worker != host || job.Attempt() != attempt {
+		return false
+	}
+	if job.Status != common.STARTING && job.Status != common.RUNNING {
+		return false
+	}
+	job.LeaseEnd = until
+	saveJob(job)
+	return true
+}
+
+// leasable reports whether a worker should be given a job, which it must
+// match the selector of and have room for on top of what is reserved. A job
+// which asks to be retried elsewhere is kept from a worker it failed on while
+// there are other workers around for it.
+func leasable(job *common.Job, host string, reserved map[string]reservation) bool {
+	if !suits(job, host) || !fits(job, host, reserved) {
+		return false
+	}
+	if !job.Retry.DifferentWorker {
+		return true
+	}
+	failed := failedWorkers(job)
+	if !failed[host] {
+		return true
+	}
+	for _, other := range Workers.Hosts() {
+		if other != host && !failed[other] && Workers.GetStatus(other) == WORKER_ONLINE && suits(job, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// expireLeases takes back every job whose lease has run out, as the worker
+// holding it has gone quiet
+func expireLeases() {
+	now := time.Now()
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	for _, job := range Commands {
+		if job.LeaseEnd.IsZero() || job.LeaseEnd.After(now) {
+			continue
+		}
+		log.Printf("Lease on job %d held by %s expired\n", job.ID, job.Worker)
+		takeBackJob(job, "lease expired", now)
+	}
+}
+
+// takeBackJob takes a job back from a worker which can no longer be relied on
+// to finish it, CommandsMtx must be held. A job which never started goes back
+// to WAITING, one which was running fails and is retried according to its
+// policy.
+func takeBackJob(job *common.Job, reason string, now time.Time) {
+	job.LeaseEnd = time.Time{}
+
+	switch {
+	case job.CancelRequested:
+		job.SetStatus(common.CANCELLED, now)
+		updateParent(job)
+	case job.PreemptedBy != 0 && job.Status == common.RUNNING:
+		requeuePreempted(job, common.Attempt{
+			Status: common.CANCELLED,
+			Error:  reason,
+			Start:  job.StatusTime(common.RUNNING),
+			End:    now,
+		})
+	case job.Status == common.STARTING:
+		job.SetStatus(common.WAITING, now)
+		job.Worker = ""
+	case job.Status == common.RUNNING:
+		finishJob(job, common.Attempt{
+			Status: common.FAILED,
+			Error:  reason,
+			Start:  job.StatusTime(common.RUNNING),
+			End:    now,
+		})
+	}
+	saveJob(job)
+}
+
+// This function implements the LeaseWork interface, workers in pull mode
+// call it for as many WAITING jobs as they have room for. Reservations are
+// worked out once a call and each job leased is added to them.
+func (*commander) LeaseWork(ctx context.Context, request *pbMessages.LeaseWorkRequest) (*pbMessages.LeaseWorkResponse, error) {
+	host := request.GetWorker()
+	if !Workers.SeePullWorker(host) {
+		return nil, status.Errorf(codes.FailedPrecondition, "worker %s has not said hello in pull mode", host)
+	}
+	if request.GetResources() != nil {
+		Workers.SetCapacity(host, request.GetResources())
+	}
+
+	response := &pbMessages.LeaseWorkResponse{
+		LeaseDuration: int64(leaseDuration),
+	}
+	until := time.Now().Add(leaseDuration)
+	reserved := reservations()
+	for _, job := range WaitingJobs() {
+		if len(response.Jobs) >= int(request.GetCapacity()) {
+			break
+		}
+		if job.FanOut() {
+			// RunWorkSender fans it out to child jobs, which can be leased
+			continue
+		}
+		if !leasable(job, host, reserved) || !leaseJob(job, host, until) {
+			continue
+		}
+		reserve(reserved, host, job)
+		if DebugLog {
+			fmt.Printf("Job %d leased by %s\n", job.ID, host)
+		}
+		response.Jobs = append(response.Jobs, &pbMessages.WorkRequest{
+			JobID:   job.ID,
+			Spec:    job.Spec(),
+			Attempt: int32(jobAttempt(job)),
+		})
+	}
+	return response, nil
+}
+
+// This function implements the RenewLease interface. Any job the worker
+// should no longer be running, because it was cancelled or preempted or its
+// lease was lost, is sent back for the worker to stop.
+func (*commander) RenewLease(ctx context.Context, request *pbMessages.RenewLeaseRequest) (*pbMessages.RenewLeaseResponse, error) {
+	host := request.GetWorker()
+	if !Workers.SeePullWorker(host) {
+		return nil, status.Errorf(codes.FailedPrecondition, "worker %s has not said hello in pull mode", host)
+	}
+	if request.GetResources() != nil {
+		Workers.SetCapacity(host, request.GetResources())
+	}
+
+	response := &pbMessages.RenewLeaseResponse{
+		LeaseDuration: int64(leaseDuration),
+	}
+	until := time.Now().Add(leaseDuration)
+	for _, lease := range request.GetLeases() {
+		job := GetJob(lease.GetJobID())
+		if job == nil || !renewLease(job, host, int(lease.GetAttempt()), until) || cancelRequested(job) || preemptRequested(job) {
+			response.Stop = append(response.Stop, lease.GetJobID())
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,83 @@
+package commander
+
+import (
+	"common"
+	"io"
+	"pbMessages"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// notSent reports whether a job with the given status has yet to be sent to a
+// worker, including while it is held back until it is due or until the jobs
+// it depends on have finished
+func notSent(stat common.Status) bool {
+	switch stat {
+	case common.WAITING, common.STARTING, common.UNSCHEDULABLE, common.DELAYED, common.BLOCKED:
+		return true
+	}
+	return false
+}
+
+// This function implements the StreamOutput interface by proxying the stream
+// served by the worker the job was sent to, so clients never need to reach
+// workers themselves
+func (*commander) StreamOutput(request *pbMessages.RequestStdOut, stream pbMessages.OutputService_StreamOutputServer) error {
+	job := GetJob(request.GetJobID())
+	if job == nil {
+		return status.Errorf(codes.NotFound, "job %d not found", request.GetJobID())
+	}
+	if job.FanOut() {
+		return status.Errorf(codes.FailedPrecondition, "job %d runs on more than one worker, its output is that of each of its jobs", job.ID)
+	}
+
+	// there is no output until a worker has accepted the job
+	for notSent(jobStatus(job)) {
+		if !request.GetFollow() {
+			return nil
+		}
+		select {
+		case <-time.After(1 * time.Second):
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+	host := jobWorker(job)
+	if host == "" {
+		// cancelled before it was ever sent anywhere
+		return nil
+	}
+
+	if !Workers.HasFeature(host, common.FEATURE_OUTPUT_STREAM) {
+		return status.Errorf(codes.Unimplemented, "worker %s does not support streaming output", host)
+	}
+
+	if ws := Workers.Session(host); ws != nil {
+		return ws.streamOutput(request, stream)
+	}
+	cc, err := Workers.Conn(host)
+	if err != nil {
+		return err
+	}
+
+	networkclient := pbMessages.NewOutputServiceClient(cc)
+	workerStream, err := networkclient.StreamOutput(stream.Context(), request)
+	if err != nil {
+		return err
+	}
+	for {
+		response, err := workerStream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = stream.Send(response)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package commander
+
+import (
+	"common"
+	"sort"
+)
+
+// reservation is what the jobs running or about to run on a worker requested
+// between them
+type reservation struct {
+	resources common.Resources
+	jobs      int
+}
+
+// reservations returns the reservation on every worker with jobs on it. The
+// work sender works them out once a pass and adds each job it sends out.
+func reservations() map[string]reservation {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	reserved := make(map[string]reservation)
+	for _, job := range Commands {
+		if job.Worker == "" || (job.Status != common.STARTING && job.Status != common.RUNNING) {
+			continue
+		}
+		r := reserved[job.Worker]
+		r.resources = r.resources.Add(job.Resources)
+		r.jobs += 1
+		reserved[job.Worker] = r
+	}
+	return reserved
+}
+
+// reserve adds a job sent to a worker to the reservation on it, and to what
+// has been sent to the worker since it last reported
+func reserve(reserved map[string]reservation, host string, job *common.Job) {
+	r := reserved[host]
+	r.resources = r.resources.Add(job.Resources)
+	r.jobs += 1
+	reserved[host] = r
+	Workers.AddSent(host, job.Resources)
+}
+
+// suits reports whether a worker could ever run a job, as it matches the
+// job's selector, is big enough for it and is the worker it is pinned to if
+// there is one
+func suits(job *common.Job, host string) bool {
+	if job.Pin != "" && job.Pin != host {
+		return false
+	}
+	return job.Selector.Matches(Workers.Labels(host)) && Workers.Capacity(host).Holds(job.Resources)
+}
+
+// fits reports whether a worker has room for a job on top of what is
+// reserved on it
+func fits(job *common.Job, host string, reserved map[string]reservation) bool {
+	r := reserved[host]
+	return Workers.Capacity(host).Fits(job.Resources, r.resources, r.jobs)
+}
+
+// bestFit orders workers with room for a job so that the one which would have
+// the least left over comes first, keeping bigger workers free for bigger jobs
+func bestFit(job *common.Job, hosts []string, reserved map[string]reservation) {
+	left := make(map[string]common.Resources)
+	for _, host := range hosts {
+		left[host] = Workers.Capacity(host).Left(reserved[host].resources.Add(job.Resources))
+	}
+	sort.SliceStable(hosts, func(i, j int) bool {
+		a, b := left[hosts[i]], left[hosts[j]]
+		if a.CPUs != b.CPUs {
+			return a.CPUs < b.CPUs
+		}
+		return a.Memory < b.Memory
+	})
+}
@@ -0,0 +1,177 @@
+package commander
+
+import (
+	"common"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PriorityClasses are the named priorities jobs can be submitted with
+var PriorityClasses = map[string]int{
+	"low":      -100,
+	"normal":   0,
+	"high":     100,
+	"critical": 1000,
+}
+
+// jobPriority works out the priority of a submitted job, from its priority
+// class if it has one
+func jobPriority(priority int, class string) (int, error) {
+	if class == "" {
+		return priority, nil
+	}
+	if priority != 0 {
+		return 0, status.Error(codes.InvalidArgument, "a job takes a priority or a priority class, not both")
+	}
+	value, found := PriorityClasses[class]
+	if !found {
+		var names []string
+		for name := range PriorityClasses {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return 0, status.Errorf(codes.InvalidArgument, "unknown priority class %s, expected one of %s", class, strings.Join(names, ", "))
+	}
+	return value, nil
+}
+
+// byPriority orders jobs highest priority first, otherwise leaving them in
+// the order they were submitted
+func byPriority(jobs []*common.Job) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Priority > jobs[j].Priority
+	})
+}
+
+// preemptRequested reports whether a job is being stopped to make room for
+// another
+func preemptRequested(job *common.Job) bool {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	return job.PreemptedBy != 0
+}
+
+// preemptFor makes room for a WAITING job no worker has room for, by stopping
+// RUNNING jobs of lower priority on the worker where that takes the fewest and
+// least important of them. The jobs stopped go back to WAITING once their
+// worker reports them gone, see requeuePreempted.
+func preemptFor(job *common.Job, reserved map[string]reservation) {
+	// lowest priority first, and of those the most recently started, which
+	// loses the least work
+	running := make(map[string][]*common.Job)
+	CommandsMtx.Lock()
+	for _, other := range Commands {
+		if other.PreemptedBy == job.ID && other.Status == common.RUNNING {
+			// room is already on its way
+			CommandsMtx.Unlock()
+			return
+		}
+		if other.Status == common.RUNNING && other.Priority < job.Priority && other.PreemptedBy == 0 && !other.CancelRequested {
+			running[other.Worker] = append(running[other.Worker], other)
+		}
+	}
+	for _, jobs := range running {
+		sort.SliceStable(jobs, func(i, j int) bool {
+			if jobs[i].Priority != jobs[j].Priority {
+				return jobs[i].Priority < jobs[j].Priority
+			}
+			return jobs[i].StatusTime(common.RUNNING).After(jobs[j].StatusTime(common.RUNNING))
+		})
+	}
+	CommandsMtx.Unlock()
+	if len(running) == 0 {
+		return
+	}
+
+	var host string
+	var victims []*common.Job
+	for candidate, jobs := range running {
+		if Workers.GetStatus(candidate) != WORKER_ONLINE || !suits(job, candidate) || !Workers.HasFeature(candidate, common.FEATURE_CANCEL) {
+			continue
+		}
+		r := reserved[candidate]
+		capacity := Workers.Capacity(candidate)
+		var freed common.Resources
+		for i, victim := range jobs {
+			freed = freed.Add(victim.Resources)
+			if !capacity.Freeing(freed, i+1).Fits(job.Resources, r.resources.Sub(freed), r.jobs-i-1) {
+				continue
+			}
+			if host == "" || worseVictims(victims, jobs[:i+1]) {
+				host, victims = candidate, jobs[:i+1]
+			}
+			break
+		}
+	}
+	if host == "" {
+		return
+	}
+
+	CommandsMtx.Lock()
+	var stopping []*common.Job
+	for _, victim := range victims {
+		if victim.Status == common.RUNNING && victim.PreemptedBy == 0 && !victim.CancelRequested {
+			victim.PreemptedBy = job.ID
+			saveJob(victim)
+			stopping = append(stopping, victim)
+		}
+	}
+	CommandsMtx.Unlock()
+
+	for _, victim := range stopping {
+		fmt.Printf("Preempting job %d on %s to make room for job %d\n", victim.ID, host, job.ID)
+		err := forwardCancel(victim, host)
+		if err != nil {
+			log.Printf("Preempting job %d failed: %v\n", victim.ID, err)
+		}
+	}
+}
+
+// worseVictims reports whether stopping the jobs in a does more harm than
+// stopping those in b, going by the highest priority stopped and then by how
+// many are stopped
+func worseVictims(a []*common.Job, b []*common.Job) bool {
+	highest := func(jobs []*common.Job) int {
+		max := jobs[0].Priority
+		for _, job := range jobs {
+			if job.Priority > max {
+				max = job.Priority
+			}
+		}
+		return max
+	}
+	if highest(a) != highest(b) {
+		return highest(a) > highest(b)
+	}
+	return len(a) > len(b)
+}
+
+// requeuePreempted sends a job which was stopped to make room for another
+// back to WAITING, recording the preemption in its history, CommandsMtx must
+// be held. The run does not count towards the job's retry policy.
+func requeuePreempted(job *common.Job, attempt common.Attempt) {
+	attempt.Number = job.Attempt()
+	attempt.Worker = job.Worker
+	attempt.PreemptedBy = job.PreemptedBy
+	job.Attempts = append(job.Attempts, attempt)
+	chargeUsage(job.Tenant, attempt)
+	job.LeaseEnd = time.Time{}
+
+	reason := fmt.Sprintf("preempted by job %d", job.PreemptedBy)
+	err := job.SetStatusFor(common.WAITING, attempt.End, reason)
+	if err != nil {
+		log.Printf("ERROR: %v\n", err)
+		return
+	}
+	fmt.Printf("Job %d was %s, requeued\n", job.ID, reason)
+	job.Worker = ""
+	job.PreemptedBy = 0
+	saveJob(job)
+	updateParent(job)
+}
@@ -0,0 +1,322 @@
+package commander
+
+import (
+	"common"
+	"context"
+	"fmt"
+	"log"
+	"pbMessages"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	Schedules    = make(map[int32]*common.Schedule)
+	SchedulesMtx sync.Mutex
+
+	// each schedule's parsed cron expression
+	crons          = make(map[int32]*common.CronSchedule)
+	lastScheduleID int32
+)
+
+// How many of a schedule's most recent jobs and missed runs are kept
+const scheduleHistory = 100
+
+// cronSchedule parses a schedule's cron expression in its time zone
+func cronSchedule(schedule *common.Schedule) (*common.CronSchedule, error) {
+	location, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %s", schedule.TimeZone)
+	}
+	return common.ParseCron(schedule.Cron, location)
+}
+
+// saveSchedule persists the current state of a schedule, SchedulesMtx must be
+// held
+func saveSchedule(schedule *common.Schedule) {
+	err := JobStore.SaveSchedule(schedule)
+	if err != nil {
+		log.Printf("ERROR: saving schedule %d: %v\n", schedule.ID, err)
+	}
+}
+
+// missRun records a run of a schedule which was never made, SchedulesMtx must
+// be held
+func missRun(schedule *common.Schedule, due time.Time) {
+	schedule.Missed += 1
+	schedule.MissedAt = append(schedule.MissedAt, due)
+	if len(schedule.MissedAt) > scheduleHistory {
+		schedule.MissedAt = schedule.MissedAt[len(schedule.MissedAt)-scheduleHistory:]
+	}
+	fmt.Printf("Schedule %d missed its run due at %v\n", schedule.ID, due)
+}
+
+// latestDue returns the most recent run of a schedule due by now, starting
+// from its next run, and records those before it as missed. SchedulesMtx must
+// be held.
+func latestDue(schedule *common.Schedule, cron *common.CronSchedule, now time.Time) time.Time {
+	due := schedule.NextRun
+	for next := cron.Next(due); !next.IsZero() && !next.After(now); next = cron.Next(next) {
+		missRun(schedule, due)
+		due = next
+	}
+	return due
+}
+
+// restoreSchedules takes the schedules loaded from JobStore, recording the
+// runs which fell due while the Commander was down as missed. A schedule
+// which catches up is left with the most recent of them due, so it runs
+// straight away. IDs carry on from lastID, so those of removed schedules are
+// never handed out again.
+func restoreSchedules(schedules map[int32]*common.Schedule, lastID int32) {
+	SchedulesMtx.Lock()
+	defer SchedulesMtx.Unlock()
+	now := time.Now()
+	lastScheduleID = lastID
+	for id, schedule := range schedules {
+		if id > lastScheduleID {
+			lastScheduleID = id
+		}
+		cron, err := cronSchedule(schedule)
+		if err != nil {
+			log.Printf("ERROR: schedule %d: %v\n", id, err)
+			continue
+		}
+		Schedules[id] = schedule
+		crons[id] = cron
+		if schedule.NextRun.IsZero() || schedule.NextRun.After(now) {
+			continue
+		}
+
+		due := latestDue(schedule, cron, now)
+		if schedule.CatchUp {
+			schedule.NextRun = due
+		} else {
+			missRun(schedule, due)
+			schedule.NextRun = cron.Next(due)
+		}
+		saveSchedule(schedule)
+	}
+}
+
+// RunScheduler creates the jobs for schedules as their runs fall due
+func RunScheduler(wg *sync.WaitGroup) {
+	for true {
+		now := time.Now()
+		SchedulesMtx.Lock()
+		var due []*common.Schedule
+		for _, schedule := range Schedules {
+			if !schedule.NextRun.IsZero() && !schedule.NextRun.After(now) {
+				due = append(due, schedule)
+			}
+		}
+		SchedulesMtx.Unlock()
+
+		sort.Slice(due, func(i, j int) bool {
+			return due[i].ID < due[j].ID
+		})
+		for _, schedule := range due {
+			runSchedule(schedule, now)
+		}
+		time.Sleep(time.Until(now.Truncate(time.Second).Add(time.Second)))
+	}
+}
+
+// runSchedule makes the run of a schedule which is due, creating a job from
+// its template unless its overlap policy says otherwise. Only the latest run
+// due is made, any before it are missed.
+func runSchedule(schedule *common.Schedule, now time.Time) {
+	SchedulesMtx.Lock()
+	if schedule.Removed {
+		SchedulesMtx.Unlock()
+		return
+	}
+	cron := crons[schedule.ID]
+	due := latestDue(schedule, cron, now)
+	template := schedule.Job
+	overlap := schedule.Overlap
+	recent := append([]int32{}, schedule.Jobs...)
+	SchedulesMtx.Unlock()
+
+	var going []*common.Job
+	CommandsMtx.Lock()
+	for _, id := range recent {
+		job := findJob(id)
+		if job != nil && !job.Status.Finished() {
+			going = append(going, job)
+		}
+	}
+	CommandsMtx.Unlock()
+
+	var job *common.Job
+	if len(going) == 0 || overlap != common.OVERLAP_SKIP {
+		if overlap == common.OVERLAP_REPLACE {
+			for _, old := range going {
+				fmt.Printf("Schedule %d replacing job %d\n", schedule.ID, old.ID)
+				err := cancelJob(old)
+				if err != nil {
+					log.Printf("Cancelling job %d of schedule %d failed: %v\n", old.ID, schedule.ID, err)
+				}
+			}
+		}
+		run := *template
+		run.Schedule = schedule.ID
+		job = AddJob(&run)
+	}
+
+	SchedulesMtx.Lock()
+	defer SchedulesMtx.Unlock()
+	if job != nil {
+		schedule.Jobs = append(schedule.Jobs, job.ID)
+		if len(schedule.Jobs) > scheduleHistory {
+			schedule.Jobs = schedule.Jobs[len(schedule.Jobs)-scheduleHistory:]
+		}
+		if DebugLog {
+			fmt.Printf("Schedule %d created job %d for its run due at %v\n", schedule.ID, job.ID, due)
+		}
+	} else {
+		schedule.Skipped += 1
+		fmt.Printf("Schedule %d skipped its run due at %v, job %d is still going\n", schedule.ID, due, going[0].ID)
+	}
+	schedule.LastRun = due
+	schedule.NextRun = cron.Next(due)
+	if !schedule.Removed {
+		saveSchedule(schedule)
+	}
+}
+
+// scheduleInfo returns the protocol buffers definition of a schedule
+func scheduleInfo(schedule *common.Schedule) *pbMessages.ScheduleInfo {
+	SchedulesMtx.Lock()
+	info := &pbMessages.ScheduleInfo{
+		ScheduleID: schedule.ID,
+		Name:       schedule.Name,
+		Cron:       schedule.Cron,
+		TimeZone:   schedule.TimeZone,
+		Overlap:    pbMessages.OverlapPolicy(schedule.Overlap),
+		CatchUp:    schedule.CatchUp,
+		Created:    schedule.Created.UnixNano(),
+		Jobs:       append([]int32{}, schedule.Jobs...),
+		Skipped:    int32(schedule.Skipped),
+		Missed:     int32(schedule.Missed),
+	}
+	if !schedule.LastRun.IsZero() {
+		info.LastRun = schedule.LastRun.UnixNano()
+	}
+	if !schedule.NextRun.IsZero() {
+		info.NextRun = schedule.NextRun.UnixNano()
+	}
+	for _, due := range schedule.MissedAt {
+		info.MissedAt = append(info.MissedAt, due.UnixNano())
+	}
+	template := schedule.Job
+	SchedulesMtx.Unlock()
+
+	// the template is never changed once the schedule is created
+	info.Job = jobInfo(template)
+	return info
+}
+
+// This function implements the CreateSchedule interface
+func (*commander) CreateSchedule(ctx context.Context, request *pbMessages.CreateScheduleRequest) (*pbMessages.ScheduleInfo, error) {
+	overlap := common.OverlapPolicy(request.GetOverlap())
+	if overlap != common.OVERLAP_ALLOW && overlap != common.OVERLAP_SKIP && overlap != common.OVERLAP_REPLACE {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown overlap policy %d", overlap)
+	}
+	job, err := jobFromRequest(request.GetJob())
+	if err != nil {
+		return nil, err
+	}
+	if !job.RunAt.IsZero() {
+		return nil, status.Error(codes.InvalidArgument, "a scheduled job runs when its schedule fires, not at a time or after a delay")
+	}
+	now := time.Now()
+	schedule := &common.Schedule{
+		Name:     request.GetName(),
+		Cron:     request.GetCron(),
+		TimeZone: request.GetTimeZone(),
+		Overlap:  overlap,
+		CatchUp:  request.GetCatchUp(),
+		Job:      job,
+		Created:  now,
+	}
+	cron, err := cronSchedule(schedule)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	schedule.NextRun = cron.Next(now)
+	if schedule.NextRun.IsZero() {
+		return nil, status.Errorf(codes.InvalidArgument, "cron expression %q never fires", schedule.Cron)
+	}
+
+	SchedulesMtx.Lock()
+	lastScheduleID += 1
+	schedule.ID = lastScheduleID
+	Schedules[schedule.ID] = schedule
+	crons[schedule.ID] = cron
+	saveSchedule(schedule)
+	SchedulesMtx.Unlock()
+	if DebugLog {
+		fmt.Printf("Created schedule %d: %s, next run at %v\n", schedule.ID, schedule.Cron, schedule.NextRun)
+	}
+	return scheduleInfo(schedule), nil
+}
+
+// findSchedule returns the schedule with the given ID, or nil if there is no
+// such schedule
+func findSchedule(scheduleID int32) *common.Schedule {
+	SchedulesMtx.Lock()
+	defer SchedulesMtx.Unlock()
+	return Schedules[scheduleID]
+}
+
+// This function implements the GetSchedule interface
+func (*commander) GetSchedule(ctx context.Context, request *pbMessages.GetScheduleRequest) (*pbMessages.ScheduleInfo, error) {
+	schedule := findSchedule(request.GetScheduleID())
+	if schedule == nil {
+		return nil, status.Errorf(codes.NotFound, "schedule %d not found", request.GetScheduleID())
+	}
+	return scheduleInfo(schedule), nil
+}
+
+// This function implements the ListSchedules interface
+func (*commander) ListSchedules(ctx context.Context, request *pbMessages.ListSchedulesRequest) (*pbMessages.ListSchedulesResponse, error) {
+	SchedulesMtx.Lock()
+	var schedules []*common.Schedule
+	for _, schedule := range Schedules {
+		schedules = append(schedules, schedule)
+	}
+	SchedulesMtx.Unlock()
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].ID < schedules[j].ID
+	})
+
+	response := &pbMessages.ListSchedulesResponse{}
+	for _, schedule := range schedules {
+		response.Schedules = append(response.Schedules, scheduleInfo(schedule))
+	}
+	return response, nil
+}
+
+// This function implements the RemoveSchedule interface. Jobs the schedule
+// has already created are left alone.
+func (*commander) RemoveSchedule(ctx context.Context, request *pbMessages.RemoveScheduleRequest) (*pbMessages.RemoveScheduleResponse, error) {
+	SchedulesMtx.Lock()
+	defer SchedulesMtx.Unlock()
+	schedule, found := Schedules[request.GetScheduleID()]
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "schedule %d not found", request.GetScheduleID())
+	}
+	schedule.Removed = true
+	saveSchedule(schedule)
+	delete(Schedules, schedule.ID)
+	delete(crons, schedule.ID)
+	if DebugLog {
+		fmt.Printf("Removed schedule %d\n", schedule.ID)
+	}
+	return &pbMessages.RemoveScheduleResponse{}, nil
+}
@@ -0,0 +1,291 @@
+package commander
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"pbMessages"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// workerSession is the Connect stream of a worker in session mode, which
+// everything the commander would otherwise dial in to deliver is sent down
+type workerSession struct {
+	host     string
+	stream   pbMessages.SessionService_ConnectServer
+	sendMtx  sync.Mutex
+	mtx      sync.Mutex
+	lastID   int64
+	pending  map[int64]*pendingRequest
+	done     chan struct{}
+	kicked   chan struct{}
+	kickOnce sync.Once
+}
+
+// pendingRequest is a request sent to a worker which is still being replied to
+type pendingRequest struct {
+	replies chan *pbMessages.WorkerMessage
+	closed  chan struct{}
+}
+
+func newWorkerSession(host string, stream pbMessages.SessionService_ConnectServer) *workerSession {
+	return &workerSession{
+		host:    host,
+		stream:  stream,
+		pending: make(map[int64]*pendingRequest),
+		done:    make(chan struct{}),
+		kicked:  make(chan struct{}),
+	}
+}
+
+// kick ends the session, e.g. when the worker is removed
+func (ws *workerSession) kick() {
+	ws.kickOnce.Do(func() {
+		close(ws.kicked)
+	})
+}
+
+func (ws *workerSession) send(message *pbMessages.CommanderMessage) error {
+	ws.sendMtx.Lock()
+	defer ws.sendMtx.Unlock()
+	return ws.stream.Send(message)
+}
+
+// open sends a request to the worker, returning its id and the pending
+// request its replies are delivered to
+func (ws *workerSession) open(message *pbMessages.CommanderMessage) (int64, *pendingRequest, error) {
+	ws.mtx.Lock()
+	ws.lastID += 1
+	id := ws.lastID
+	request := &pendingRequest{
+		replies: make(chan *pbMessages.WorkerMessage, 16),
+		closed:  make(chan struct{}),
+	}
+	ws.pending[id] = request
+	ws.mtx.Unlock()
+
+	message.Id = id
+	err := ws.send(message)
+	if err != nil {
+		ws.close(id)
+		return 0, nil, status.Errorf(codes.Unavailable, "session with worker %s failed: %v", ws.host, err)
+	}
+	return id, request, nil
+}
+
+// close stops delivering replies to a request
+func (ws *workerSession) close(id int64) {
+	ws.mtx.Lock()
+	defer ws.mtx.Unlock()
+	request, found := ws.pending[id]
+	if found {
+		delete(ws.pending, id)
+		close(request.closed)
+	}
+}
+
+// deliver hands a reply from the worker to the request it answers
+func (ws *workerSession) deliver(reply *pbMessages.WorkerMessage) {
+	ws.mtx.Lock()
+	request, found := ws.pending[reply.GetReplyTo()]
+	ws.mtx.Unlock()
+	if !found {
+		return
+	}
+	select {
+	case request.replies <- reply:
+	case <-request.closed:
+	case <-ws.done:
+	}
+}
+
+// recv waits for the next reply to a request, turning errors sent by the
+// worker back into gRPC errors
+func (ws *workerSession) recv(ctx context.Context, request *pendingRequest) (*pbMessages.WorkerMessage, error) {
+	select {
+	case reply := <-request.replies:
+		if reply.GetErrorCode() != int32(codes.OK) {
+			return nil, status.Error(codes.Code(reply.GetErrorCode()), reply.GetError())
+		}
+		return reply, nil
+	case <-ws.done:
+		return nil, status.Errorf(codes.Unavailable, "session with worker %s closed", ws.host)
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+		}
+		return nil, status.Error(codes.Canceled, ctx.Err().Error())
+	}
+}
+
+// call sends a request to the worker and waits for its reply
+func (ws *workerSession) call(ctx context.Context, message *pbMessages.CommanderMessage) (*pbMessages.WorkerMessage, error) {
+	id, request, err := ws.open(message)
+	if err != nil {
+		return nil, err
+	}
+	defer ws.close(id)
+	return ws.recv(ctx, request)
+}
+
+// reply answers a request from the worker
+func (ws *workerSession) reply(id int64, message *pbMessages.CommanderMessage, err error) {
+	message.ReplyTo = id
+	if err != nil {
+		message.ErrorCode = int32(status.Code(err))
+		message.Error = status.Convert(err).Message()
+	}
+	err = ws.send(message)
+	if err != nil && DebugLog {
+		log.Printf("Replying to %s failed: %v\n", ws.host, err)
+	}
+}
+
+// Heartbeat sends a ping down the session, so a session can stand in for a
+// HeartbeatServiceClient
+func (ws *workerSession) Heartbeat(ctx context.Context, in *pbMessages.Ping, opts ...grpc.CallOption) (*pbMessages.Pong, error) {
+	reply, err := ws.call(ctx, &pbMessages.CommanderMessage{
+		Message: &pbMessages.CommanderMessage_Ping{Ping: in},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetPong(), nil
+}
+
+// Work sends a job down the session, so a session can stand in for a
+// WorkServiceClient
+func (ws *workerSession) Work(ctx context.Context, in *pbMessages.WorkRequest, opts ...grpc.CallOption) (*pbMessages.WorkResponse, error) {
+	reply, err := ws.call(ctx, &pbMessages.CommanderMessage{
+		Message: &pbMessages.CommanderMessage_Work{Work: in},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetWork(), nil
+}
+
+// Cancel sends a cancel down the session
+func (ws *workerSession) Cancel(ctx context.Context, in *pbMessages.CancelJobRequest, opts ...grpc.CallOption) (*pbMessages.WorkResponse, error) {
+	reply, err := ws.call(ctx, &pbMessages.CommanderMessage{
+		Message: &pbMessages.CommanderMessage_Cancel{Cancel: in},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetWork(), nil
+}
+
+// streamOutput proxies a job's output from the worker to a client
+func (ws *workerSession) streamOutput(request *pbMessages.RequestStdOut, stream pbMessages.OutputService_StreamOutputServer) error {
+	id, pending, err := ws.open(&pbMessages.CommanderMessage{
+		Message: &pbMessages.CommanderMessage_Output{Output: request},
+	})
+	if err != nil {
+		return err
+	}
+	defer ws.close(id)
+
+	for {
+		reply, err := ws.recv(stream.Context(), pending)
+		if err != nil {
+			if stream.Context().Err() != nil {
+				// let the worker know nobody is listening any more
+				ws.send(&pbMessages.CommanderMessage{ReplyTo: id, HangUp: true})
+			}
+			return err
+		}
+		if reply.GetOutput() != nil {
+			err = stream.Send(reply.GetOutput())
+			if err != nil {
+				ws.send(&pbMessages.CommanderMessage{ReplyTo: id, HangUp: true})
+				return err
+			}
+		}
+		if reply.GetEnd() {
+			return nil
+		}
+	}
+}
+
+// This function implements the Connect interface. The stream stays open for
+// as long as the worker is connected, the worker is OFFLINE once it closes.
+func (*commander) Connect(stream pbMessages.SessionService_ConnectServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	hello := first.GetHello()
+	if hello == nil {
+		return status.Error(codes.InvalidArgument, "a session must start with a HelloRequest")
+	}
+	if DebugLog {
+		fmt.Printf("Received session HelloRequest from %s at %s [%s]\n", workerID(hello), hello.GetIp(), hello.GetFqdn())
+	}
+	response, err := negotiate(hello)
+	if err != nil {
+		return err
+	}
+
+	host := workerID(hello)
+	ws := newWorkerSession(host, stream)
+	Workers.AttachSession(hello, response, ws)
+	defer func() {
+		close(ws.done)
+		Workers.DetachSession(host, ws)
+	}()
+	ws.reply(first.GetId(), &pbMessages.CommanderMessage{
+		Message: &pbMessages.CommanderMessage_Hello{Hello: response},
+	}, nil)
+
+	ended := make(chan error, 1)
+	go func() {
+		ended <- ws.serve()
+	}()
+	select {
+	case err = <-ended:
+	case <-ws.kicked:
+		err = status.Error(codes.Aborted, "worker removed")
+	}
+	if DebugLog {
+		log.Printf("Session with %s ended: %v\n", host, err)
+	}
+	return err
+}
+
+// serve handles everything the worker sends until the session ends
+func (ws *workerSession) serve() error {
+	for {
+		message, err := ws.stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if message.GetReplyTo() != 0 {
+			ws.deliver(message)
+			continue
+		}
+
+		switch request := message.Message.(type) {
+		case *pbMessages.WorkerMessage_Status:
+			go func(id int64) {
+				ack, err := (&commander{}).ReportStatus(ws.stream.Context(), request.Status)
+				reply := &pbMessages.CommanderMessage{}
+				if ack != nil {
+					reply.Message = &pbMessages.CommanderMessage_Status{Status: ack}
+				}
+				ws.reply(id, reply, err)
+			}(message.GetId())
+		default:
+			ws.reply(message.GetId(), &pbMessages.CommanderMessage{},
+				status.Errorf(codes.Unimplemented, "unexpected %T in session", request))
+		}
+	}
+}
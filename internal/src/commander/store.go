@@ -0,0 +1,88 @@
+package commander
+
+import (
+	"common"
+	"fmt"
+	"log"
+	"store"
+	"time"
+)
+
+// JobStore is where jobs, the worker registry and schedules are persisted
+var JobStore store.Store = store.NewMemoryStore()
+
+// saveJob persists the current state of a job, CommandsMtx must be held
+func saveJob(job *common.Job) {
+	err := JobStore.SaveJob(job)
+	if err != nil {
+		log.Printf("ERROR: saving job %d: %v\n", job.ID, err)
+	}
+}
+
+// saveWorker persists a worker registry entry, WorkersMtx must be held
+func saveWorker(host string, pWorkerData *WorkerData) {
+	err := JobStore.SaveWorker(&store.WorkerRecord{Host: host, IP: pWorkerData.ip, Fqdn: pWorkerData.fqdn})
+	if err != nil {
+		log.Printf("ERROR: saving worker %s: %v\n", host, err)
+	}
+}
+
+// removeWorker persists the removal of a worker, WorkersMtx must be held
+func removeWorker(host string) {
+	err := JobStore.SaveWorker(&store.WorkerRecord{Host: host, Removed: true})
+	if err != nil {
+		log.Printf("ERROR: removing worker %s: %v\n", host, err)
+	}
+}
+
+// Restore loads the jobs, workers and schedules kept in JobStore. Workers start
+// OFFLINE until the heartbeat reaches them again.
+func Restore() error {
+	state, err := JobStore.Load()
+	if err != nil {
+		return err
+	}
+
+	CommandsMtx.Lock()
+	// finished jobs are eventually left out of the store, their IDs are not
+	// handed out again
+	lastJobID = state.LastJobID
+	lastWorkflowID = state.LastWorkflowID
+	for _, job := range state.Jobs {
+		// a send cut short by the restart is sent again, running jobs are
+		// left alone as their worker will report back once we are up. A job
+		// which has been fanned out follows its children.
+		if job.Status == common.STARTING && len(job.Children) == 0 {
+			job.SetStatus(common.WAITING, time.Now())
+			job.LeaseEnd = time.Time{}
+			saveJob(job)
+		}
+		if job.Tenant == "" {
+			// submitted before there were tenants
+			job.Tenant = DEFAULT_TENANT
+		}
+		if !job.Status.Finished() {
+			addTimers(job)
+		}
+		if job.ID > lastJobID {
+			lastJobID = job.ID
+		}
+		if job.Workflow > lastWorkflowID {
+			lastWorkflowID = job.Workflow
+		}
+		Commands = append(Commands, job)
+	}
+	CommandsMtx.Unlock()
+
+	WorkersMtx.Lock()
+	for host, worker := range state.Workers {
+		// connected to again when the worker next says hello
+		Workers[host] = &WorkerData{ip: worker.IP, fqdn: worker.Fqdn, status: WORKER_OFFLINE, offlineSince: time.Now()}
+	}
+	WorkersMtx.Unlock()
+
+	restoreSchedules(state.Schedules, state.LastScheduleID)
+
+	fmt.Printf("Restored %d jobs, %d workers and %d schedules\n", len(state.Jobs), len(state.Workers), len(state.Schedules))
+	return nil
+}
@@ -0,0 +1,150 @@
+package commander
+
+import (
+	"common"
+	"container/heap"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// jobTimer is a time something may happen to a job which has not been sent
+// out yet, it being released from DELAYED or expiring
+type jobTimer struct {
+	at  time.Time
+	job *common.Job
+}
+
+// timerHeap keeps timers earliest first, as a container/heap
+type timerHeap []jobTimer
+
+func (h timerHeap) Len() int {
+	return len(h)
+}
+
+func (h timerHeap) Less(i, j int) bool {
+	return h[i].at.Before(h[j].at)
+}
+
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *timerHeap) Push(x interface{}) {
+	*h = append(*h, x.(jobTimer))
+}
+
+func (h *timerHeap) Pop() interface{} {
+	old := *h
+	timer := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return timer
+}
+
+var (
+	timers    timerHeap
+	timersMtx sync.Mutex
+	// poked when a timer is added, in case it is earlier than the one
+	// RunTimers is waiting for
+	timersChanged = make(chan struct{}, 1)
+)
+
+// addTimers sets timers for when a job is due to run and when it expires,
+// CommandsMtx must be held. A job which is BLOCKED or DELAYED has no expiry
+// timer until it becomes WAITING.
+func addTimers(job *common.Job) {
+	if !job.RunAt.IsZero() {
+		addTimer(job, job.RunAt)
+	}
+	addExpiryTimer(job)
+}
+
+// addExpiryTimer sets a timer for when a job expires, if it does,
+// CommandsMtx must be held
+func addExpiryTimer(job *common.Job) {
+	if expires := job.ExpiresAt(); !expires.IsZero() {
+		addTimer(job, expires)
+	}
+}
+
+// addTimer sets a timer for a job to go off at the given time
+func addTimer(job *common.Job, at time.Time) {
+	timersMtx.Lock()
+	heap.Push(&timers, jobTimer{at: at, job: job})
+	timersMtx.Unlock()
+	select {
+	case timersChanged <- struct{}{}:
+	default:
+	}
+}
+
+// RunTimers releases DELAYED jobs when they are due and expires jobs which
+// have not been sent out by the end of their TTL, sleeping until the next
+// timer in between
+func RunTimers(wg *sync.WaitGroup) {
+	for true {
+		now := time.Now()
+		var due []*common.Job
+		wait := time.Minute
+		timersMtx.Lock()
+		for len(timers) > 0 && !timers[0].at.After(now) {
+			due = append(due, heap.Pop(&timers).(jobTimer).job)
+		}
+		if len(timers) > 0 {
+			wait = timers[0].at.Sub(now)
+		}
+		timersMtx.Unlock()
+
+		if len(due) > 0 {
+			fireTimers(due, now)
+		}
+		select {
+		case <-timersChanged:
+		case <-time.After(wait):
+		}
+	}
+}
+
+// fireTimers does what is due for jobs whose timers have gone off. A job
+// which was never sent out EXPIRES once past its TTL, otherwise a DELAYED
+// job goes to WAITING once it is due. Anything else has moved on since its
+// timer was set and is left alone.
+func fireTimers(jobs []*common.Job, now time.Time) {
+	CommandsMtx.Lock()
+	released := false
+	for _, job := range jobs {
+		expires := job.ExpiresAt()
+		if !expires.IsZero() && !expires.After(now) && len(job.Attempts) == 0 && job.Status.CanTransition(common.EXPIRED) {
+			reason := fmt.Sprintf("not sent out within %v of being due", job.TTL)
+			err := job.SetStatusFor(common.EXPIRED, now, reason)
+			if err != nil {
+				log.Printf("ERROR: %v\n", err)
+				continue
+			}
+			job.Error = reason
+			fmt.Printf("Job %d expired, %s\n", job.ID, reason)
+			saveJob(job)
+			updateParent(job)
+			continue
+		}
+		if job.Status == common.DELAYED && !job.RunAt.After(now) {
+			err := job.SetStatus(common.WAITING, now)
+			if err != nil {
+				log.Printf("ERROR: %v\n", err)
+				continue
+			}
+			if DebugLog {
+				fmt.Printf("Job %d is due to run\n", job.ID)
+			}
+			saveJob(job)
+			addExpiryTimer(job)
+			released = true
+		}
+	}
+	CommandsMtx.Unlock()
+
+	if released {
+		wakeWorkSender()
+	}
+}
@@ -1,5 +1,19 @@
 package commander
 
+import (
+	"common"
+	"context"
+	"fmt"
+	"log"
+	"pbMessages"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
 type Status int
 
 const (
@@ -7,68 +21,584 @@ const (
 	WORKER_OFFLINE               // 1
 )
 
+func (s Status) String() string {
+	switch s {
+	case WORKER_ONLINE:
+		return "ONLINE"
+	case WORKER_OFFLINE:
+		return "OFFLINE"
+	}
+	return "UNKNOWN"
+}
+
 type WorkerData struct {
-	fqdn        string
-	networkErrs int
-	status      Status
+	ip              string // address the worker said hello from, connected to in push mode
+	fqdn            string
+	addresses       []string
+	labels          map[string]string
+	capacity        common.Capacity // as last advertised by the worker
+	busyUntil       time.Time       // the worker turned work away, so is left alone until then
+	networkErrs     int
+	status          Status
+	offlineSince    time.Time // when the worker last went OFFLINE
+	protocolVersion int32
+	features        []string
+	conn            *grpc.ClientConn // long-lived connection to the worker's work port
+	heartbeatConn   *grpc.ClientConn // connection to the heartbeat port of a worker which negotiated PROTOCOL_V2
+	session         *workerSession   // set instead of conn while a worker in session mode is connected
+	pull            bool             // the worker leases its own work
+	lastSeen        time.Time        // when a worker in pull mode last asked for work or renewed a lease
 }
 
-// WorkerMap is a map of worker nodes and info related to each node
+// WorkerMap is a map of worker nodes and info related to each node, by
+// worker ID
 type WorkerMap map[string]*WorkerData
 
-func (wm WorkerMap) AddWorker(server string) {
-	_, found := wm[server]
+// dialWorker opens the connection every message to a worker in push mode is
+// sent over, and starts watching it
+func dialWorker(server string, ip string) *grpc.ClientConn {
+	cc := dialPort(server, ip, 50052)
+	if cc != nil {
+		go watchWorkerConn(server, cc)
+	}
+	return cc
+}
+
+// dialPort opens a long-lived connection to one of a worker's ports
+func dialPort(server string, ip string, port int) *grpc.ClientConn {
+	cc, err := grpc.Dial(fmt.Sprintf("%s:%d", ip, port), grpc.WithInsecure(), grpc.WithKeepaliveParams(common.ClientKeepalive))
+	if err != nil {
+		// only possible with bad dial options, the connection itself is made lazily
+		log.Printf("ERROR: connecting to worker %s: %v\n", server, err)
+		return nil
+	}
+	return cc
+}
+
+// closeConns closes the connections to a worker in push mode, WorkersMtx must
+// be held
+func (pWorkerData *WorkerData) closeConns() {
+	if pWorkerData.conn != nil {
+		pWorkerData.conn.Close()
+		pWorkerData.conn = nil
+	}
+	if pWorkerData.heartbeatConn != nil {
+		pWorkerData.heartbeatConn.Close()
+		pWorkerData.heartbeatConn = nil
+	}
+}
+
+// watchWorkerConn follows the state of a worker's connection, taking the
+// worker OFFLINE when the connection fails and back ONLINE once it is ready
+// again, until the connection is closed
+func watchWorkerConn(server string, cc *grpc.ClientConn) {
+	state := cc.GetState()
+	for state != connectivity.Shutdown {
+		if DebugLog {
+			fmt.Printf("Connection to %s is %v\n", server, state)
+		}
+		switch state {
+		case connectivity.Ready:
+			if Workers.setConnStatus(server, cc, WORKER_ONLINE) {
+				fmt.Printf("Setting %s to ONLINE\n", server)
+			}
+		case connectivity.TransientFailure:
+			if Workers.setConnStatus(server, cc, WORKER_OFFLINE) {
+				fmt.Printf("Setting %s to OFFLINE\n", server)
+			}
+		}
+		cc.WaitForStateChange(context.Background(), state)
+		state = cc.GetState()
+	}
+}
+
+// setConnStatus sets the status of a worker if cc is still its connection,
+// returning true if the status changed
+func (wm WorkerMap) setConnStatus(server string, cc *grpc.ClientConn, stat Status) bool {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if !found || pWorkerData.conn != cc {
+		return false
+	}
+	if stat == WORKER_ONLINE {
+		pWorkerData.networkErrs = 0
+	}
+	return pWorkerData.setStatus(stat)
+}
+
+// setStatus sets the status of a worker, noting when it goes OFFLINE, and
+// returns true if the status changed. WorkersMtx must be held.
+func (pWorkerData *WorkerData) setStatus(stat Status) bool {
+	if pWorkerData.status == stat {
+		return false
+	}
+	if stat == WORKER_OFFLINE {
+		pWorkerData.offlineSince = time.Now()
+	}
+	pWorkerData.status = stat
+	return true
+}
+
+// workerID returns the ID a worker is known by, workers which do not send one
+// are known by their IP address
+func workerID(hello *pbMessages.HelloRequest) string {
+	if hello.GetId() != "" {
+		return hello.GetId()
+	}
+	return hello.GetIp()
+}
+
+// register adds a worker which has said hello, or updates the addresses and
+// the negotiated protocol of one we already know. WorkersMtx must be held.
+func (wm WorkerMap) register(hello *pbMessages.HelloRequest, negotiated *pbMessages.HelloResponse) *WorkerData {
+	id := workerID(hello)
+	pWorkerData, found := wm[id]
+	if !found {
+		pWorkerData = &WorkerData{status: WORKER_OFFLINE, offlineSince: time.Now()}
+		wm[id] = pWorkerData
+	}
+	fqdn := hello.GetFqdn()
+	if fqdn == "" {
+		fqdn = hello.GetIp()
+	}
+	if found && pWorkerData.ip != hello.GetIp() {
+		fmt.Printf("Worker %s moved from %s to %s\n", id, pWorkerData.ip, hello.GetIp())
+		pWorkerData.closeConns()
+	}
+	if !found || pWorkerData.ip != hello.GetIp() || pWorkerData.fqdn != fqdn {
+		pWorkerData.ip = hello.GetIp()
+		pWorkerData.fqdn = fqdn
+		saveWorker(id, pWorkerData)
+	}
+	pWorkerData.addresses = hello.GetAddresses()
+	pWorkerData.labels = hello.GetLabels()
+	pWorkerData.capacity = common.CapacityFromSpec(hello.GetResources())
+	pWorkerData.protocolVersion = negotiated.GetVersion()
+	pWorkerData.features = negotiated.GetFeatures()
+	return pWorkerData
+}
+
+// AddWorker adds a worker in push mode, or updates one we already know
+func (wm WorkerMap) AddWorker(hello *pbMessages.HelloRequest, negotiated *pbMessages.HelloResponse) {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	id := workerID(hello)
+	_, found := wm[id]
+	pWorkerData := wm.register(hello, negotiated)
 	if !found {
-		WorkersMtx.Lock()
-		wm[server] = &WorkerData{server, 0, WORKER_ONLINE}
+		pWorkerData.setStatus(WORKER_ONLINE)
+	}
+	if pWorkerData.conn == nil {
+		pWorkerData.conn = dialWorker(id, pWorkerData.ip)
+	}
+	// before PROTOCOL_V3 heartbeats are only served on the heartbeat port
+	if pWorkerData.protocolVersion < common.PROTOCOL_V3 && pWorkerData.heartbeatConn == nil {
+		pWorkerData.heartbeatConn = dialPort(id, pWorkerData.ip, 50051)
+	}
+	if pWorkerData.protocolVersion >= common.PROTOCOL_V3 && pWorkerData.heartbeatConn != nil {
+		pWorkerData.heartbeatConn.Close()
+		pWorkerData.heartbeatConn = nil
+	}
+	pWorkerData.pull = false
+}
+
+// AttachSession adds a worker in session mode, or brings one we already know
+// ONLINE over its new session
+func (wm WorkerMap) AttachSession(hello *pbMessages.HelloRequest, negotiated *pbMessages.HelloResponse, ws *workerSession) {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	id := workerID(hello)
+	pWorkerData := wm.register(hello, negotiated)
+	// the worker may have been in push mode
+	pWorkerData.closeConns()
+	if pWorkerData.status != WORKER_ONLINE {
+		fmt.Printf("Setting %s to ONLINE\n", id)
+	}
+	pWorkerData.session = ws
+	pWorkerData.pull = false
+	pWorkerData.setStatus(WORKER_ONLINE)
+	pWorkerData.networkErrs = 0
+}
+
+// AddPullWorker adds a worker in pull mode, which is never connected to, or
+// updates one we already know
+func (wm WorkerMap) AddPullWorker(hello *pbMessages.HelloRequest, negotiated *pbMessages.HelloResponse) {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData := wm.register(hello, negotiated)
+	// the worker may have been in push mode
+	pWorkerData.closeConns()
+	pWorkerData.pull = true
+	pWorkerData.seen(workerID(hello))
+}
+
+// SeePullWorker records that a worker in pull mode is still around, returning
+// false if it is not a worker in pull mode we know about
+func (wm WorkerMap) SeePullWorker(server string) bool {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if !found || !pWorkerData.pull {
+		return false
+	}
+	pWorkerData.seen(server)
+	return true
+}
+
+// seen brings a worker in pull mode ONLINE, WorkersMtx must be held
+func (pWorkerData *WorkerData) seen(server string) {
+	if pWorkerData.status != WORKER_ONLINE {
+		fmt.Printf("Setting %s to ONLINE\n", server)
+	}
+	pWorkerData.setStatus(WORKER_ONLINE)
+	pWorkerData.networkErrs = 0
+	pWorkerData.lastSeen = time.Now()
+}
+
+// IsPull reports whether a worker leases its own work
+func (wm WorkerMap) IsPull(server string) bool {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	return found && pWorkerData.pull
+}
+
+// LastSeen returns when a worker in pull mode was last heard from
+func (wm WorkerMap) LastSeen(server string) time.Time {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if !found {
+		return time.Time{}
+	}
+	return pWorkerData.lastSeen
+}
+
+// DetachSession takes a worker OFFLINE when its session ends, unless it has
+// already started another
+func (wm WorkerMap) DetachSession(server string, ws *workerSession) {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if !found || pWorkerData.session != ws {
+		return
+	}
+	fmt.Printf("Setting %s to OFFLINE\n", server)
+	pWorkerData.session = nil
+	pWorkerData.setStatus(WORKER_OFFLINE)
+}
+
+// RemoveWorker forgets a worker and closes the connection or session to it.
+// A worker which is still running joins again the next time it says hello.
+func (wm WorkerMap) RemoveWorker(server string) bool {
+	WorkersMtx.Lock()
+	pWorkerData, found := wm[server]
+	if found {
+		delete(wm, server)
+		removeWorker(server)
+		pWorkerData.closeConns()
+	}
+	WorkersMtx.Unlock()
+	if !found {
+		return false
+	}
+	if pWorkerData.session != nil {
+		pWorkerData.session.kick()
+	}
+	return true
+}
+
+// CloseAll closes the connection or session to every worker, ready for shutdown
+func (wm WorkerMap) CloseAll() {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	for _, pWorkerData := range wm {
+		pWorkerData.closeConns()
+		if pWorkerData.session != nil {
+			pWorkerData.session.kick()
+		}
+	}
+}
+
+// HeartbeatClient returns the client heartbeats are sent to a worker with,
+// over its connection or session, or the connection to its heartbeat port if
+// it negotiated PROTOCOL_V2
+func (wm WorkerMap) HeartbeatClient(server string) (pbMessages.HeartbeatServiceClient, error) {
+	if ws := wm.Session(server); ws != nil {
+		return ws, nil
+	}
+	WorkersMtx.Lock()
+	pWorkerData, found := wm[server]
+	if found && pWorkerData.heartbeatConn != nil {
+		cc := pWorkerData.heartbeatConn
 		WorkersMtx.Unlock()
+		return pbMessages.NewHeartbeatServiceClient(cc), nil
+	}
+	WorkersMtx.Unlock()
+	cc, err := wm.Conn(server)
+	if err != nil {
+		return nil, err
+	}
+	return pbMessages.NewHeartbeatServiceClient(cc), nil
+}
+
+// WorkClient returns the client work is sent to a worker with, over its
+// connection or session
+func (wm WorkerMap) WorkClient(server string) (pbMessages.WorkServiceClient, error) {
+	if ws := wm.Session(server); ws != nil {
+		return ws, nil
+	}
+	cc, err := wm.Conn(server)
+	if err != nil {
+		return nil, err
+	}
+	return pbMessages.NewWorkServiceClient(cc), nil
+}
+
+// Session returns the session of a worker in session mode, or nil if the
+// worker is in push mode or is not connected
+func (wm WorkerMap) Session(server string) *workerSession {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if !found {
+		return nil
+	}
+	return pWorkerData.session
+}
+
+// Conn returns the connection to a worker, or an Unavailable error if the
+// worker is not in the map
+func (wm WorkerMap) Conn(server string) (*grpc.ClientConn, error) {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if !found || pWorkerData.conn == nil {
+		return nil, status.Errorf(codes.Unavailable, "no connection to worker %s", server)
+	}
+	return pWorkerData.conn, nil
+}
+
+// HasFeature reports whether a worker negotiated the given feature
+func (wm WorkerMap) HasFeature(server string, feature string) bool {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if !found {
+		return false
+	}
+	for _, f := range pWorkerData.features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// ProtocolVersion returns the protocol version agreed with a worker, or 0 if
+// the worker is not in the map
+func (wm WorkerMap) ProtocolVersion(server string) int32 {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if !found {
+		return 0
+	}
+	return pWorkerData.protocolVersion
+}
+
+// Labels returns the labels a worker advertised
+func (wm WorkerMap) Labels(server string) map[string]string {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if !found {
+		return nil
+	}
+	return pWorkerData.labels
+}
+
+// Capacity returns what a worker last advertised it has to run jobs with
+func (wm WorkerMap) Capacity(server string) common.Capacity {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if !found {
+		return common.Capacity{}
+	}
+	return pWorkerData.capacity
+}
+
+// SetCapacity records what a worker has to run jobs with, as it is refreshed
+// by heartbeats, which count every job sent to it so far
+func (wm WorkerMap) SetCapacity(server string, resources *pbMessages.WorkerResources) {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if found {
+		pWorkerData.capacity = common.CapacityFromSpec(resources)
+		if pWorkerData.capacity.SlotsUsed < pWorkerData.capacity.Slots {
+			// a slot has freed up, no need to wait any longer
+			pWorkerData.busyUntil = time.Time{}
+		}
+	}
+}
+
+// AddSent counts a job sent to a worker against the free memory and disk it
+// last reported, until it reports them again
+func (wm WorkerMap) AddSent(server string, resources common.Resources) {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if found {
+		pWorkerData.capacity.Sent = pWorkerData.capacity.Sent.Add(resources)
+	}
+}
+
+// SetBusy leaves a worker which turned work away alone for a while
+func (wm WorkerMap) SetBusy(server string, backoff time.Duration) {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if found {
+		pWorkerData.busyUntil = time.Now().Add(backoff)
 	}
 }
 
+// IsBusy reports whether a worker recently turned work away
+func (wm WorkerMap) IsBusy(server string) bool {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	return found && time.Now().Before(pWorkerData.busyUntil)
+}
+
+// Hosts returns every worker in the map
+func (wm WorkerMap) Hosts() []string {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	var hosts []string
+	for host, _ := range wm {
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
 func (wm WorkerMap) AddNetError(server string) {
-	_, found := wm[server]
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
 	if found {
-		WorkersMtx.Lock()
-		pWorkerData := wm[server]
 		pWorkerData.networkErrs += 1
-		WorkersMtx.Unlock()
 	}
 }
 
 func (wm WorkerMap) ResetNetError(server string) {
-	_, found := wm[server]
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
 	if found {
-		WorkersMtx.Lock()
-		pWorkerData := wm[server]
 		pWorkerData.networkErrs = 0
-		WorkersMtx.Unlock()
 	}
 }
 
 func (wm WorkerMap) GetNetErrors(server string) int {
-	_, found := wm[server]
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
 	if found {
-		pWorkerData := wm[server]
 		return pWorkerData.networkErrs
 	}
 	return -1
 }
 
 func (wm WorkerMap) GetStatus(server string) Status {
-	_, found := wm[server]
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
 	if found {
-		pWorkerData := wm[server]
 		return pWorkerData.status
 	}
 	return WORKER_OFFLINE
 }
 
 func (wm WorkerMap) SetStatus(server string, stat Status) {
-	_, found := wm[server]
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
 	if found {
-		WorkersMtx.Lock()
-		pWorkerData := wm[server]
-		pWorkerData.status = stat
-		WorkersMtx.Unlock()
+		pWorkerData.setStatus(stat)
+	}
+}
+
+// Lost reports whether the jobs sent to a worker in push or session mode
+// should be taken back from it, as it has been OFFLINE for longer than
+// workerLostAfter or has been removed
+func (wm WorkerMap) Lost(server string) bool {
+	WorkersMtx.Lock()
+	defer WorkersMtx.Unlock()
+	pWorkerData, found := wm[server]
+	if !found {
+		return true
+	}
+	return !pWorkerData.pull && pWorkerData.status == WORKER_OFFLINE && time.Since(pWorkerData.offlineSince) > workerLostAfter
+}
+
+// mode returns how the commander talks to a worker
+func (pWorkerData *WorkerData) mode() string {
+	if pWorkerData.pull {
+		return "pull"
+	}
+	if pWorkerData.conn != nil {
+		return "push"
+	}
+	if pWorkerData.session != nil {
+		return "session"
+	}
+	return ""
+}
+
+// This function implements the ListWorkers interface
+func (*commander) ListWorkers(ctx context.Context, request *pbMessages.ListWorkersRequest) (*pbMessages.ListWorkersResponse, error) {
+	response := &pbMessages.ListWorkersResponse{}
+	reserved := reservations()
+	WorkersMtx.Lock()
+	for host, pWorkerData := range Workers {
+		r := reserved[host]
+		response.Workers = append(response.Workers, &pbMessages.WorkerInfo{
+			Id:              host,
+			Ip:              pWorkerData.ip,
+			Addresses:       pWorkerData.addresses,
+			Labels:          pWorkerData.labels,
+			Fqdn:            pWorkerData.fqdn,
+			Status:          pWorkerData.status.String(),
+			NetworkErrors:   int32(pWorkerData.networkErrs),
+			ProtocolVersion: pWorkerData.protocolVersion,
+			Features:        pWorkerData.features,
+			Mode:            pWorkerData.mode(),
+			Resources:       pWorkerData.capacity.Spec(),
+			Reserved: &pbMessages.Resources{
+				Cpus:   r.resources.CPUs,
+				Memory: r.resources.Memory,
+				Disk:   r.resources.Disk,
+			},
+			Jobs: int32(r.jobs),
+		})
+	}
+	WorkersMtx.Unlock()
+	return response, nil
+}
+
+// This function implements the RemoveWorker interface
+func (*commander) RemoveWorker(ctx context.Context, request *pbMessages.RemoveWorkerRequest) (*pbMessages.RemoveWorkerResponse, error) {
+	id := request.GetId()
+	if id == "" {
+		id = request.GetIp()
+	}
+	if !Workers.RemoveWorker(id) {
+		return nil, status.Errorf(codes.NotFound, "worker %s not found", id)
 	}
+	fmt.Printf("Removed worker %s\n", id)
+	return &pbMessages.RemoveWorkerResponse{}, nil
 }
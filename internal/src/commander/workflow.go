@@ -0,0 +1,333 @@
+package commander
+
+import (
+	"common"
+	"context"
+	"fmt"
+	"log"
+	"pbMessages"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sortWorkflow checks the jobs of a submitted workflow have unique names and
+// only depend on each other, returning them in an order they can run in. Each
+// job comes after the jobs it depends on and otherwise keeps its place.
+func sortWorkflow(jobs []*pbMessages.WorkflowJob) ([]*pbMessages.WorkflowJob, error) {
+	if len(jobs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a workflow needs at least one job")
+	}
+	byName := make(map[string]*pbMessages.WorkflowJob)
+	for _, job := range jobs {
+		if job.GetName() == "" {
+			return nil, status.Error(codes.InvalidArgument, "every job in a workflow needs a name")
+		}
+		if _, found := byName[job.GetName()]; found {
+			return nil, status.Errorf(codes.InvalidArgument, "more than one job is named %s", job.GetName())
+		}
+		byName[job.GetName()] = job
+	}
+	for _, job := range jobs {
+		for _, name := range job.GetDependsOn() {
+			if _, found := byName[name]; !found {
+				return nil, status.Errorf(codes.InvalidArgument, "job %s depends on %s, which is not in the workflow", job.GetName(), name)
+			}
+		}
+	}
+
+	placed := make(map[string]bool)
+	var sorted []*pbMessages.WorkflowJob
+	for len(sorted) < len(jobs) {
+		var next *pbMessages.WorkflowJob
+		for _, job := range jobs {
+			if placed[job.GetName()] {
+				continue
+			}
+			ready := true
+			for _, name := range job.GetDependsOn() {
+				if !placed[name] {
+					ready = false
+				}
+			}
+			if ready {
+				next = job
+				break
+			}
+		}
+		if next == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "jobs %s depend on each other in a cycle", dependencyCycle(jobs, placed, byName))
+		}
+		placed[next.GetName()] = true
+		sorted = append(sorted, next)
+	}
+	return sorted, nil
+}
+
+// dependencyCycle finds a cycle among the jobs sortWorkflow could not place,
+// each of which depends on at least one other of them, and describes it
+func dependencyCycle(jobs []*pbMessages.WorkflowJob, placed map[string]bool, byName map[string]*pbMessages.WorkflowJob) string {
+	var path []string
+	seen := make(map[string]int)
+	job := ""
+	for _, candidate := range jobs {
+		if !placed[candidate.GetName()] {
+			job = candidate.GetName()
+			break
+		}
+	}
+	for {
+		if start, found := seen[job]; found {
+			return strings.Join(append(path[start:], job), " -> ")
+		}
+		seen[job] = len(path)
+		path = append(path, job)
+		for _, name := range byName[job].GetDependsOn() {
+			if !placed[name] {
+				job = name
+				break
+			}
+		}
+	}
+}
+
+// releaseBlocked moves BLOCKED jobs on to WAITING once the jobs they depend on
+// have all succeeded, or to DELAYED if they are not due yet. A job depending
+// on one which finished without succeeding, or which is gone, is SKIPPED or
+// FAILED as its workflow's failure policy says. Jobs come after those they
+// depend on in Commands, so this reaches every job downstream of a failure in
+// one pass.
+func releaseBlocked() {
+	CommandsMtx.Lock()
+	defer CommandsMtx.Unlock()
+	now := time.Now()
+	byID := make(map[int32]*common.Job)
+	for _, job := range Commands {
+		if job.Workflow != 0 {
+			byID[job.ID] = job
+		}
+	}
+
+	for _, job := range Commands {
+		if job.Status != common.BLOCKED {
+			continue
+		}
+		reason := ""
+		ready := true
+		for _, id := range job.DependsOn {
+			dependency := byID[id]
+			if dependency == nil {
+				// it cannot succeed any more
+				reason = fmt.Sprintf("job %d is gone", id)
+				break
+			}
+			if dependency.Status == common.SUCCESS {
+				continue
+			}
+			if dependency.Status.Finished() {
+				reason = fmt.Sprintf("job %d (%s) was %v", dependency.ID, dependency.Name, dependency.Status)
+				break
+			}
+			ready = false
+		}
+
+		if reason != "" {
+			to := common.SKIPPED
+			if job.OnFailure == common.FAIL_DEPENDENTS {
+				to = common.FAILED
+				job.Error = reason
+			}
+			err := job.SetStatusFor(to, now, reason)
+			if err != nil {
+				log.Printf("ERROR: %v\n", err)
+				continue
+			}
+			fmt.Printf("Job %d of workflow %d %v, %s\n", job.ID, job.Workflow, to, reason)
+			saveJob(job)
+			continue
+		}
+		if ready {
+			to := common.WAITING
+			if job.RunAt.After(now) {
+				// its timer moves it on to WAITING
+				to = common.DELAYED
+			}
+			err := job.SetStatus(to, now)
+			if err != nil {
+				log.Printf("ERROR: %v\n", err)
+				continue
+			}
+			if DebugLog {
+				fmt.Printf("Job %d of workflow %d is ready to run\n", job.ID, job.Workflow)
+			}
+			saveJob(job)
+			addExpiryTimer(job)
+		}
+	}
+}
+
+// workflowJobs returns the jobs in a workflow in an order they can run in,
+// CommandsMtx must be held
+func workflowJobs(workflowID int32) []*common.Job {
+	var jobs []*common.Job
+	for _, job := range Commands {
+		if job.Workflow == workflowID {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// workflowStatus sums up how the jobs in a workflow are getting on,
+// CommandsMtx must be held. The workflow is WAITING until any of its jobs
+// has started and RUNNING until they have all finished. It is then SUCCESS
+// if they all succeeded, FAILED if any of them failed or expired and
+// CANCELLED otherwise.
+func workflowStatus(jobs []*common.Job) common.Status {
+	finished, started, succeeded, failed := true, false, true, false
+	for _, job := range jobs {
+		switch job.Status {
+		case common.WAITING, common.BLOCKED, common.DELAYED, common.UNSCHEDULABLE:
+			started = started || len(job.Attempts) > 0
+		default:
+			started = true
+		}
+		finished = finished && job.Status.Finished()
+		succeeded = succeeded && job.Status == common.SUCCESS
+		failed = failed || job.Status == common.FAILED || job.Status == common.TIMED_OUT || job.Status == common.EXPIRED
+	}
+	switch {
+	case !started:
+		return common.WAITING
+	case !finished:
+		return common.RUNNING
+	case succeeded:
+		return common.SUCCESS
+	case failed:
+		return common.FAILED
+	}
+	return common.CANCELLED
+}
+
+// workflowInfo returns the status of a workflow with each of its jobs, or nil
+// if there is no such workflow
+func workflowInfo(workflowID int32) *pbMessages.WorkflowInfo {
+	CommandsMtx.Lock()
+	jobs := workflowJobs(workflowID)
+	if len(jobs) == 0 {
+		CommandsMtx.Unlock()
+		return nil
+	}
+	info := &pbMessages.WorkflowInfo{
+		WorkflowID: workflowID,
+		Status:     int32(workflowStatus(jobs)),
+		OnFailure:  pbMessages.FailurePolicy(jobs[0].OnFailure),
+	}
+	CommandsMtx.Unlock()
+
+	for _, job := range jobs {
+		info.Jobs = append(info.Jobs, jobInfo(job))
+	}
+	return info
+}
+
+// This function implements the SubmitWorkflow interface. The jobs are added
+// together, those which depend on others as BLOCKED until releaseBlocked
+// lets them go.
+func (*commander) SubmitWorkflow(ctx context.Context, request *pbMessages.SubmitWorkflowRequest) (*pbMessages.WorkflowInfo, error) {
+	policy := common.FailurePolicy(request.GetOnFailure())
+	if policy != common.SKIP_DEPENDENTS && policy != common.FAIL_DEPENDENTS {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown failure policy %d", policy)
+	}
+	sorted, err := sortWorkflow(request.GetJobs())
+	if err != nil {
+		return nil, err
+	}
+	var jobs []*common.Job
+	for _, spec := range sorted {
+		job, err := jobFromRequest(spec.GetJob())
+		if err != nil {
+			return nil, status.Errorf(status.Code(err), "job %s: %s", spec.GetName(), status.Convert(err).Message())
+		}
+		job.Name = spec.GetName()
+		job.OnFailure = policy
+		jobs = append(jobs, job)
+	}
+
+	CommandsMtx.Lock()
+	lastWorkflowID += 1
+	workflowID := lastWorkflowID
+	ids := make(map[string]int32)
+	for i, job := range jobs {
+		job.Workflow = workflowID
+		for _, name := range sorted[i].GetDependsOn() {
+			job.DependsOn = append(job.DependsOn, ids[name])
+		}
+		addJob(job)
+		ids[job.Name] = job.ID
+	}
+	CommandsMtx.Unlock()
+	if DebugLog {
+		fmt.Printf("Queued workflow %d of %d jobs\n", workflowID, len(jobs))
+	}
+
+	return workflowInfo(workflowID), nil
+}
+
+// This function implements the GetWorkflow interface
+func (*commander) GetWorkflow(ctx context.Context, request *pbMessages.GetWorkflowRequest) (*pbMessages.WorkflowInfo, error) {
+	info := workflowInfo(request.GetWorkflowID())
+	if info == nil {
+		return nil, status.Errorf(codes.NotFound, "workflow %d not found", request.GetWorkflowID())
+	}
+	return info, nil
+}
+
+// This function implements the ListWorkflows interface
+func (*commander) ListWorkflows(ctx context.Context, request *pbMessages.ListWorkflowsRequest) (*pbMessages.ListWorkflowsResponse, error) {
+	CommandsMtx.Lock()
+	var workflowIDs []int32
+	seen := make(map[int32]bool)
+	for _, job := range Commands {
+		if job.Workflow != 0 && !seen[job.Workflow] {
+			seen[job.Workflow] = true
+			workflowIDs = append(workflowIDs, job.Workflow)
+		}
+	}
+	CommandsMtx.Unlock()
+
+	response := &pbMessages.ListWorkflowsResponse{}
+	for _, workflowID := range workflowIDs {
+		response.Workflows = append(response.Workflows, workflowInfo(workflowID))
+	}
+	return response, nil
+}
+
+// This function implements the CancelWorkflow interface, cancelling every job
+// in the workflow which has not finished yet. Jobs are cancelled downstream
+// first, so those still BLOCKED end up CANCELLED rather than SKIPPED.
+func (*commander) CancelWorkflow(ctx context.Context, request *pbMessages.CancelWorkflowRequest) (*pbMessages.WorkflowInfo, error) {
+	CommandsMtx.Lock()
+	jobs := workflowJobs(request.GetWorkflowID())
+	stat := workflowStatus(jobs)
+	CommandsMtx.Unlock()
+	if len(jobs) == 0 {
+		return nil, status.Errorf(codes.NotFound, "workflow %d not found", request.GetWorkflowID())
+	}
+	if stat.Finished() {
+		return nil, status.Errorf(codes.FailedPrecondition, "workflow %d is %v and can no longer be cancelled", request.GetWorkflowID(), stat)
+	}
+
+	for i := len(jobs) - 1; i >= 0; i-- {
+		if jobStatus(jobs[i]).Finished() {
+			continue
+		}
+		err := cancelJob(jobs[i])
+		if err != nil && status.Code(err) != codes.FailedPrecondition {
+			log.Printf("Cancelling job %d of workflow %d failed: %v\n", jobs[i].ID, request.GetWorkflowID(), err)
+		}
+	}
+	return workflowInfo(request.GetWorkflowID()), nil
+}
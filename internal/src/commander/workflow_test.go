@@ -0,0 +1,104 @@
+package commander
+
+import (
+	"common"
+	"pbMessages"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// workflow builds the jobs of a workflow from name:dependency,dependency
+// descriptions
+func workflow(jobs ...string) []*pbMessages.WorkflowJob {
+	var workflow []*pbMessages.WorkflowJob
+	for _, job := range jobs {
+		name, deps := job, ""
+		if i := strings.Index(job, ":"); i >= 0 {
+			name, deps = job[:i], job[i+1:]
+		}
+		wj := &pbMessages.WorkflowJob{Name: name}
+		if deps != "" {
+			wj.DependsOn = strings.Split(deps, ",")
+		}
+		workflow = append(workflow, wj)
+	}
+	return workflow
+}
+
+func TestSortWorkflow(t *testing.T) {
+	tests := []struct {
+		name  string
+		jobs  []*pbMessages.WorkflowJob
+		want  []string
+		error string
+	}{
+		{"single job", workflow("a"), []string{"a"}, ""},
+		{"independent jobs keep their place", workflow("c", "a", "b"), []string{"c", "a", "b"}, ""},
+		{"chain given backwards", workflow("c:b", "b:a", "a"), []string{"a", "b", "c"}, ""},
+		{"diamond", workflow("d:b,c", "b:a", "c:a", "a"), []string{"a", "b", "c", "d"}, ""},
+		{"already in order", workflow("a", "b:a", "c:a,b"), []string{"a", "b", "c"}, ""},
+		{"no jobs", nil, nil, "at least one job"},
+		{"unnamed job", workflow("a", ""), nil, "needs a name"},
+		{"duplicate names", workflow("a", "b", "a"), nil, "more than one job is named a"},
+		{"unknown dependency", workflow("a", "b:c"), nil, "depends on c, which is not in the workflow"},
+		{"depends on itself", workflow("a", "b:b"), nil, "b -> b"},
+		{"cycle", workflow("a:b", "b:a"), nil, "a -> b -> a"},
+		{"cycle downstream of a job", workflow("a", "b:a,d", "c:b", "d:c"), nil, "b -> d -> c -> b"},
+	}
+	for _, test := range tests {
+		sorted, err := sortWorkflow(test.jobs)
+		if test.error != "" {
+			if status.Code(err) != codes.InvalidArgument || !strings.Contains(status.Convert(err).Message(), test.error) {
+				t.Errorf("%s: error %v, want InvalidArgument containing %q", test.name, err, test.error)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		var names []string
+		for _, job := range sorted {
+			names = append(names, job.GetName())
+		}
+		if !reflect.DeepEqual(names, test.want) {
+			t.Errorf("%s: sorted %v, want %v", test.name, names, test.want)
+		}
+	}
+}
+
+func TestReleaseBlocked(t *testing.T) {
+	defer func(jobs []*common.Job) { Commands = jobs }(Commands)
+
+	tests := []struct {
+		name       string
+		dependency common.Status
+		missing    bool
+		onFailure  common.FailurePolicy
+		want       common.Status
+	}{
+		{"dependency running", common.RUNNING, false, common.SKIP_DEPENDENTS, common.BLOCKED},
+		{"dependency succeeded", common.SUCCESS, false, common.SKIP_DEPENDENTS, common.WAITING},
+		{"dependency failed, skip", common.FAILED, false, common.SKIP_DEPENDENTS, common.SKIPPED},
+		{"dependency failed, fail", common.FAILED, false, common.FAIL_DEPENDENTS, common.FAILED},
+		{"dependency gone, skip", 0, true, common.SKIP_DEPENDENTS, common.SKIPPED},
+		{"dependency gone, fail", 0, true, common.FAIL_DEPENDENTS, common.FAILED},
+	}
+	for _, test := range tests {
+		dependency := &common.Job{ID: 1, Workflow: 1, Status: test.dependency}
+		job := &common.Job{ID: 2, Workflow: 1, Status: common.BLOCKED, DependsOn: []int32{1}, OnFailure: test.onFailure}
+		Commands = []*common.Job{dependency, job}
+		if test.missing {
+			Commands = Commands[1:]
+		}
+
+		releaseBlocked()
+		if job.Status != test.want {
+			t.Errorf("%s: job is %v, want %v", test.name, job.Status, test.want)
+		}
+	}
+}
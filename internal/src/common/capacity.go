@@ -0,0 +1,136 @@
+package common
+
+import (
+	"pbMessages"
+)
+
+// Capacity is what a worker advertises it has to run jobs with. Sizes are 0
+// when the worker cannot tell, and are then not checked.
+type Capacity struct {
+	CPUs        int
+	TotalMemory int64 // bytes
+	FreeMemory  int64 // bytes
+	TotalDisk   int64 // bytes
+	FreeDisk    int64 // bytes
+	OS          string
+	Arch        string
+	Slots       int // most jobs run at once, at least 1 when known
+	SlotsUsed   int
+	Queued      int       // jobs waiting for a slot
+	QueueSize   int       // most jobs the worker queues before turning work away
+	Sent        Resources // needed by jobs sent to the worker since it last reported
+}
+
+// Add returns the resources needed by both r and other
+func (r Resources) Add(other Resources) Resources {
+	return Resources{
+		CPUs:   r.CPUs + other.CPUs,
+		Memory: r.Memory + other.Memory,
+		Disk:   r.Disk + other.Disk,
+	}
+}
+
+// Sub returns the resources left of r once other is taken away
+func (r Resources) Sub(other Resources) Resources {
+	return Resources{
+		CPUs:   r.CPUs - other.CPUs,
+		Memory: r.Memory - other.Memory,
+		Disk:   r.Disk - other.Disk,
+	}
+}
+
+// Freeing returns what a worker would report once jobs needing r between them
+// have stopped running on it
+func (c Capacity) Freeing(r Resources, jobs int) Capacity {
+	if c.FreeMemory > 0 {
+		c.FreeMemory += r.Memory
+	}
+	if c.FreeDisk > 0 {
+		c.FreeDisk += r.Disk
+	}
+	c.SlotsUsed -= jobs
+	if c.SlotsUsed < 0 {
+		c.SlotsUsed = 0
+	}
+	return c
+}
+
+// Holds reports whether a worker is big enough for jobs needing r between
+// them, whatever else it is doing
+func (c Capacity) Holds(r Resources) bool {
+	if c.CPUs > 0 && r.CPUs > float64(c.CPUs) {
+		return false
+	}
+	if c.TotalMemory > 0 && r.Memory > c.TotalMemory {
+		return false
+	}
+	if c.TotalDisk > 0 && r.Disk > c.TotalDisk {
+		return false
+	}
+	return true
+}
+
+// Fits reports whether a job needing r can run on a worker which already has
+// jobs running on it needing reserved between them. Free memory, disk and
+// slots are as the worker last reported them, less what has been sent to it
+// since, so the job itself has to fit in those too.
+func (c Capacity) Fits(r Resources, reserved Resources, jobs int) bool {
+	if c.SlotsUsed > jobs {
+		jobs = c.SlotsUsed
+	}
+	if c.Slots > 0 && jobs >= c.Slots {
+		return false
+	}
+	if c.FreeMemory > 0 && r.Memory > c.FreeMemory-c.Sent.Memory {
+		return false
+	}
+	if c.FreeDisk > 0 && r.Disk > c.FreeDisk-c.Sent.Disk {
+		return false
+	}
+	return c.Holds(reserved.Add(r))
+}
+
+// Left returns what remains of a worker once jobs needing reserved between
+// them are running on it
+func (c Capacity) Left(reserved Resources) Resources {
+	return Resources{
+		CPUs:   float64(c.CPUs) - reserved.CPUs,
+		Memory: c.TotalMemory - reserved.Memory,
+		Disk:   c.TotalDisk - reserved.Disk,
+	}
+}
+
+// CapacityFromSpec builds a worker's capacity from its protocol buffers
+// definition
+func CapacityFromSpec(spec *pbMessages.WorkerResources) Capacity {
+	return Capacity{
+		CPUs:        int(spec.GetCpus()),
+		TotalMemory: spec.GetTotalMemory(),
+		FreeMemory:  spec.GetFreeMemory(),
+		TotalDisk:   spec.GetTotalDisk(),
+		FreeDisk:    spec.GetFreeDisk(),
+		OS:          spec.GetOs(),
+		Arch:        spec.GetArch(),
+		Slots:       int(spec.GetSlots()),
+		SlotsUsed:   int(spec.GetSlotsUsed()),
+		Queued:      int(spec.GetQueued()),
+		QueueSize:   int(spec.GetQueueSize()),
+	}
+}
+
+// Spec returns the protocol buffers definition of a worker's capacity
+func (c Capacity) Spec() *pbMessages.WorkerResources {
+	return &pbMessages.WorkerResources{
+		Cpus:        int32(c.CPUs),
+		TotalMemory: c.TotalMemory,
+		FreeMemory:  c.FreeMemory,
+		TotalDisk:   c.TotalDisk,
+		FreeDisk:    c.FreeDisk,
+		Os:          c.OS,
+		Arch:        c.Arch,
+		Slots:       int32(c.Slots),
+		SlotsUsed:   int32(c.SlotsUsed),
+		Queued:      int32(c.Queued),
+		QueueSize:   int32(c.QueueSize),
+	}
+}
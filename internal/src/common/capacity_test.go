@@ -0,0 +1,49 @@
+package common
+
+import "testing"
+
+func TestCapacityFits(t *testing.T) {
+	gb := int64(1 << 30)
+	worker := Capacity{CPUs: 4, TotalMemory: 8 * gb, FreeMemory: 6 * gb, TotalDisk: 100 * gb, FreeDisk: 50 * gb, Slots: 2}
+	tests := []struct {
+		name     string
+		capacity Capacity
+		job      Resources
+		reserved Resources
+		jobs     int
+		want     bool
+	}{
+		{"idle", worker, Resources{CPUs: 1, Memory: gb}, Resources{}, 0, true},
+		{"whole worker", worker, Resources{CPUs: 4, Memory: 6 * gb}, Resources{}, 0, true},
+		{"too many CPUs", worker, Resources{CPUs: 5}, Resources{}, 0, false},
+		{"CPUs taken", worker, Resources{CPUs: 2}, Resources{CPUs: 3}, 1, false},
+		{"more memory than is free", worker, Resources{Memory: 7 * gb}, Resources{}, 0, false},
+		{"memory taken", worker, Resources{Memory: 4 * gb}, Resources{Memory: 5 * gb}, 1, false},
+		{"more disk than is free", worker, Resources{Disk: 60 * gb}, Resources{}, 0, false},
+		{"memory sent since the report", Capacity{FreeMemory: 6 * gb, Sent: Resources{Memory: 5 * gb}}, Resources{Memory: 2 * gb}, Resources{}, 0, false},
+		{"disk sent since the report", Capacity{FreeDisk: 50 * gb, Sent: Resources{Disk: 40 * gb}}, Resources{Disk: 20 * gb}, Resources{}, 0, false},
+		{"room left after what was sent", Capacity{FreeMemory: 6 * gb, Sent: Resources{Memory: 4 * gb}}, Resources{Memory: 2 * gb}, Resources{}, 0, true},
+		{"slots full", worker, Resources{}, Resources{}, 2, false},
+		{"slots full as reported", Capacity{Slots: 2, SlotsUsed: 2}, Resources{}, Resources{}, 0, false},
+		{"one slot left", Capacity{Slots: 2, SlotsUsed: 1}, Resources{}, Resources{}, 1, true},
+		// sizes the worker did not report are not checked
+		{"nothing reported", Capacity{}, Resources{CPUs: 64, Memory: 100 * gb, Disk: 100 * gb}, Resources{}, 10, true},
+	}
+	for _, test := range tests {
+		if got := test.capacity.Fits(test.job, test.reserved, test.jobs); got != test.want {
+			t.Errorf("%s: Fits = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestCapacityFreeing(t *testing.T) {
+	gb := int64(1 << 30)
+	worker := Capacity{CPUs: 2, TotalMemory: 4 * gb, FreeMemory: gb, Slots: 1, SlotsUsed: 1}
+	job := Resources{CPUs: 2, Memory: 2 * gb}
+	if worker.Fits(job, job, 1) {
+		t.Fatalf("job fits before anything is freed")
+	}
+	if !worker.Freeing(job, 1).Fits(job, Resources{}, 0) {
+		t.Errorf("job does not fit once the one like it is freed")
+	}
+}
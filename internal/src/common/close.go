@@ -7,12 +7,17 @@ import (
 	"syscall"
 )
 
-func SetupCloseHandler() {
-	c := make(chan os.Signal)
+// SetupCloseHandler exits cleanly on Ctrl+C or SIGTERM, running each of the
+// cleanup functions first
+func SetupCloseHandler(cleanup ...func()) {
+	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-c
 		fmt.Println("\r- Ctrl+C pressed in Terminal")
+		for _, fn := range cleanup {
+			fn()
+		}
 		os.Exit(0)
 	}()
 }
@@ -0,0 +1,293 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed cron expression, which fires at the times matching
+// every one of its fields in its time zone
+type CronSchedule struct {
+	second, minute, hour, dom, month, dow uint64 // bit n set if n matches
+	domStar, dowStar                      bool   // the field was * or ?
+	location                              *time.Location
+}
+
+// cronField is the range of values one field of a cron expression takes
+type cronField struct {
+	name     string
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	secondField = cronField{name: "second", min: 0, max: 59}
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	domField    = cronField{name: "day of month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is Sunday too
+	dowField = cronField{name: "day of week", min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronMacros are the shorthands accepted in place of a full expression
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// ParseCron parses a cron expression of five fields, minute hour day-of-month
+// month day-of-week, or of six with seconds first, to be read in location.
+// Fields take *, ?, values, ranges, lists and steps as in "*/15", "1-5" or
+// "mon,wed,fri", and the whole expression may instead be a macro like @daily.
+// As with cron, a day matches if either the day of month or the day of week
+// does when both are given.
+func ParseCron(expr string, location *time.Location) (*CronSchedule, error) {
+	if macro, found := cronMacros[strings.ToLower(strings.TrimSpace(expr))]; found {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron expression %q has %d fields, expected 5 or 6", expr, len(fields))
+	}
+
+	c := &CronSchedule{location: location}
+	var err error
+	parsers := []struct {
+		bits  *uint64
+		field cronField
+	}{
+		{&c.second, secondField},
+		{&c.minute, minuteField},
+		{&c.hour, hourField},
+		{&c.dom, domField},
+		{&c.month, monthField},
+		{&c.dow, dowField},
+	}
+	for i, parser := range parsers {
+		*parser.bits, err = parser.field.parse(fields[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domStar = fields[3] == "*" || fields[3] == "?"
+	c.dowStar = fields[5] == "*" || fields[5] == "?"
+	return c, nil
+}
+
+// parse turns one field of a cron expression into the values it matches
+func (f cronField) parse(expr string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rng, step := part, uint(1)
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.ParseUint(part[i+1:], 10, 8)
+			if err != nil || n == 0 {
+				return 0, fmt.Errorf("invalid step in %s %q", f.name, part)
+			}
+			rng, step = part[:i], uint(n)
+		}
+
+		var low, high uint
+		var err error
+		switch {
+		case rng == "*" || rng == "?":
+			low, high = f.min, f.max
+		case strings.Contains(rng, "-"):
+			i := strings.Index(rng, "-")
+			low, err = f.value(rng[:i])
+			if err == nil {
+				high, err = f.value(rng[i+1:])
+			}
+		default:
+			low, err = f.value(rng)
+			high = low
+			if step > 1 {
+				// a/n runs from a to the end of the range
+				high = f.max
+			}
+		}
+		if err != nil {
+			return 0, err
+		}
+		if low > high {
+			return 0, fmt.Errorf("%s range %q runs backwards", f.name, part)
+		}
+		for n := low; n <= high; n += step {
+			bits |= 1 << n
+		}
+	}
+	return bits, nil
+}
+
+// value reads a single value of a field, by number or by name
+func (f cronField) value(s string) (uint, error) {
+	if n, found := f.names[strings.ToLower(s)]; found {
+		return n, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 8)
+	if err != nil || uint(n) < f.min || uint(n) > f.max {
+		return 0, fmt.Errorf("invalid %s %q, expected %d to %d", f.name, s, f.min, f.max)
+	}
+	return uint(n), nil
+}
+
+// dayMatches reports whether t falls on a day the schedule fires on
+func (c *CronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Next returns the first time after t the schedule fires, or the zero time if
+// it never does in the next five years, e.g. for the 30th of February. The
+// schedule fires once for each time on the clock in its time zone which
+// matches, so when daylight saving time ends a time shown twice only fires
+// the first time round, and when it starts a time the clocks skip fires at
+// the moment they skip it.
+func (c *CronSchedule) Next(t time.Time) time.Time {
+	wall := wallClock(t.In(c.location))
+	for {
+		wall = c.nextWall(wall)
+		if wall.IsZero() {
+			return wall
+		}
+		// an earlier time on the clock may fire at the same moment, or have
+		// already fired the first time round
+		next := c.instant(wall)
+		if next.After(t) {
+			return next
+		}
+	}
+}
+
+// wallClock returns the time the clocks show at t, as that time in UTC so it
+// can be moved about without running into daylight saving changes
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// instant returns the first moment the clocks in the schedule's time zone
+// show wall, or if they skip over it the moment they do so
+func (c *CronSchedule) instant(wall time.Time) time.Time {
+	var first time.Time
+	// the offsets in force either side of any change
+	var before, after time.Duration
+	for i, guess := range []time.Time{wall.Add(-24 * time.Hour), wall.Add(24 * time.Hour)} {
+		_, offset := guess.In(c.location).Zone()
+		if i == 0 {
+			before = time.Duration(offset) * time.Second
+		} else {
+			after = time.Duration(offset) * time.Second
+		}
+		t := wall.Add(-time.Duration(offset) * time.Second)
+		if wallClock(t.In(c.location)).Equal(wall) && (first.IsZero() || t.Before(first)) {
+			first = t
+		}
+	}
+	if !first.IsZero() {
+		return first.In(c.location)
+	}
+
+	// the clocks went forward past wall somewhere between these two
+	low, high := wall.Add(-after), wall.Add(-before)
+	for high.Sub(low) > time.Second {
+		middle := low.Add(high.Sub(low) / 2).Truncate(time.Second)
+		if wallClock(middle.In(c.location)).Before(wall) {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+	return high.In(c.location)
+}
+
+// nextWall returns the first time on the clock after wall the schedule
+// matches, or the zero time if there is none in the next five years. Each
+// field is moved forward in turn until it matches, starting again from the
+// month whenever one wraps around.
+func (c *CronSchedule) nextWall(wall time.Time) time.Time {
+	t := wall.Truncate(time.Second).Add(time.Second)
+	limit := t.Year() + 5
+	// whether a field has moved, which resets those after it
+	moved := false
+
+wrap:
+	if t.Year() > limit {
+		return time.Time{}
+	}
+
+	for c.month&(1<<uint(t.Month())) == 0 {
+		if !moved {
+			moved = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		}
+		t = t.AddDate(0, 1, 0)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+
+	for !c.dayMatches(t) {
+		if !moved {
+			moved = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		}
+		t = t.AddDate(0, 0, 1)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+
+	for c.hour&(1<<uint(t.Hour())) == 0 {
+		if !moved {
+			moved = true
+			t = t.Truncate(time.Hour)
+		}
+		t = t.Add(time.Hour)
+		if t.Hour() == 0 {
+			goto wrap
+		}
+	}
+
+	for c.minute&(1<<uint(t.Minute())) == 0 {
+		if !moved {
+			moved = true
+			t = t.Truncate(time.Minute)
+		}
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+
+	for c.second&(1<<uint(t.Second())) == 0 {
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto wrap
+		}
+	}
+	return t
+}
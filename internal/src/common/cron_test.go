@@ -0,0 +1,115 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr string
+		ok   bool
+	}{
+		{"* * * * *", true},
+		{"0 30 2 * * *", true},
+		{"*/15 9-17 * * mon-fri", true},
+		{"0 0 1,15 * sun", true},
+		{"@daily", true},
+		{"@Hourly", true},
+		{"* * * *", false},
+		{"60 * * * *", false},
+		{"* 24 * * *", false},
+		{"* * 0 * *", false},
+		{"* * * 13 *", false},
+		{"* * * * 8", false},
+		{"*/0 * * * *", false},
+		{"5-1 * * * *", false},
+		{"@fortnightly", false},
+	}
+	for _, test := range tests {
+		_, err := ParseCron(test.expr, time.UTC)
+		if (err == nil) != test.ok {
+			t.Errorf("ParseCron(%q) = %v, want ok %v", test.expr, err, test.ok)
+		}
+	}
+}
+
+// runs returns the first n times a schedule fires after from
+func runs(t *testing.T, expr string, loc *time.Location, from time.Time, n int) []time.Time {
+	cron, err := ParseCron(expr, loc)
+	if err != nil {
+		t.Fatalf("ParseCron(%q): %v", expr, err)
+	}
+	var times []time.Time
+	for next := cron.Next(from); len(times) < n; next = cron.Next(next) {
+		if next.IsZero() {
+			break
+		}
+		times = append(times, next)
+	}
+	return times
+}
+
+func TestCronNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	utc := func(s string) time.Time {
+		at, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return at
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		loc  *time.Location
+		from string
+		want []string
+	}{
+		{"every minute", "* * * * *", time.UTC, "2026-05-01T10:00:30Z",
+			[]string{"2026-05-01T10:01:00Z", "2026-05-01T10:02:00Z"}},
+		{"weekdays", "0 9 * * mon-fri", time.UTC, "2026-05-01T10:00:00Z",
+			[]string{"2026-05-04T09:00:00Z", "2026-05-05T09:00:00Z"}},
+		{"day of month or week", "0 0 13 * fri", time.UTC, "2026-03-01T00:00:00Z",
+			[]string{"2026-03-06T00:00:00Z", "2026-03-13T00:00:00Z", "2026-03-20T00:00:00Z"}},
+		{"end of february", "0 0 29 2 *", time.UTC, "2026-01-01T00:00:00Z",
+			[]string{"2028-02-29T00:00:00Z"}},
+		{"never", "0 0 30 2 *", time.UTC, "2026-01-01T00:00:00Z", nil},
+		{"in a time zone", "0 30 9 * * *", newYork, "2026-05-01T00:00:00Z",
+			[]string{"2026-05-01T13:30:00Z", "2026-05-02T13:30:00Z"}},
+		// 01:30 is shown twice on 2026-11-01 and only fires the first time
+		{"clocks go back", "0 30 1 * * *", newYork, "2026-10-31T12:00:00Z",
+			[]string{"2026-11-01T05:30:00Z", "2026-11-02T06:30:00Z"}},
+		{"clocks go back, starting in the repeat", "0 50 1 * * *", newYork, "2026-11-01T06:10:00Z",
+			[]string{"2026-11-02T06:50:00Z"}},
+		// 02:30 is skipped on 2026-03-08 and fires as the clocks go forward
+		{"clocks go forward", "0 30 2 * * *", newYork, "2026-03-07T12:00:00Z",
+			[]string{"2026-03-08T07:00:00Z", "2026-03-09T06:30:00Z"}},
+		{"several skipped", "0 0,20,40 2 * * *", newYork, "2026-03-08T00:00:00Z",
+			[]string{"2026-03-08T07:00:00Z", "2026-03-09T06:00:00Z"}},
+		{"hourly across the change", "0 0 * * * *", newYork, "2026-03-08T05:30:00Z",
+			[]string{"2026-03-08T06:00:00Z", "2026-03-08T07:00:00Z", "2026-03-08T08:00:00Z"}},
+		{"hourly as the clocks go back", "0 0 * * * *", newYork, "2026-11-01T04:30:00Z",
+			[]string{"2026-11-01T05:00:00Z", "2026-11-01T07:00:00Z", "2026-11-01T08:00:00Z"}},
+	}
+	for _, test := range tests {
+		n := len(test.want)
+		if n == 0 {
+			n = 1
+		}
+		got := runs(t, test.expr, test.loc, utc(test.from), n)
+		if len(got) != len(test.want) {
+			t.Errorf("%s: fired at %v, want %v", test.name, got, test.want)
+			continue
+		}
+		for i, at := range got {
+			if !at.Equal(utc(test.want[i])) {
+				t.Errorf("%s: run %d at %v, want %s", test.name, i, at.UTC(), test.want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// KeyValues is a flag which may be given more than once as key=value
+type KeyValues map[string]string
+
+func (kv KeyValues) String() string {
+	var pairs []string
+	for key, value := range kv {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (kv KeyValues) Set(pair string) error {
+	parts := strings.SplitN(pair, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("expected key=value, got %s", pair)
+	}
+	kv[parts[0]] = parts[1]
+	return nil
+}
+
+// ByteSize is a flag taking a number of bytes with an optional K, M, G or T
+// suffix (powers of 1024)
+type ByteSize int64
+
+func (size *ByteSize) String() string {
+	return strconv.FormatInt(int64(*size), 10)
+}
+
+func (size *ByteSize) Set(value string) error {
+	multiplier := int64(1)
+	suffixes := "KMGT"
+	upper := strings.ToUpper(strings.TrimSuffix(strings.ToUpper(value), "B"))
+	if n := len(upper); n > 0 {
+		if i := strings.IndexByte(suffixes, upper[n-1]); i >= 0 {
+			multiplier = int64(1) << (10 * uint(i+1))
+			upper = upper[:n-1]
+		}
+	}
+	n, err := strconv.ParseInt(upper, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid size %s", value)
+	}
+	*size = ByteSize(n * multiplier)
+	return nil
+}
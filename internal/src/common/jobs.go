@@ -1,18 +1,319 @@
 package common
 
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
 type Status int
 
 const (
-	WAITING   Status = iota // 0
-	STARTING                // 1
-	RUNNING                 // 2
-	SUCCESS                 // 3
-	FAILED                  // 4
-	CANCELLED               // 5
+	WAITING       Status = iota // 0
+	STARTING                    // 1
+	RUNNING                     // 2
+	SUCCESS                     // 3
+	FAILED                      // 4
+	CANCELLED                   // 5
+	TIMED_OUT                   // 6
+	UNSCHEDULABLE               // 7, no worker matches the job's selector
+	BLOCKED                     // 8, waiting for the jobs it depends on to succeed
+	SKIPPED                     // 9, a job it depends on did not succeed
+	DELAYED                     // 10, held back until it is due to run
+	EXPIRED                     // 11, not sent out in time to run
+)
+
+func (s Status) String() string {
+	switch s {
+	case WAITING:
+		return "WAITING"
+	case STARTING:
+		return "STARTING"
+	case RUNNING:
+		return "RUNNING"
+	case SUCCESS:
+		return "SUCCESS"
+	case FAILED:
+		return "FAILED"
+	case CANCELLED:
+		return "CANCELLED"
+	case TIMED_OUT:
+		return "TIMED_OUT"
+	case UNSCHEDULABLE:
+		return "UNSCHEDULABLE"
+	case BLOCKED:
+		return "BLOCKED"
+	case SKIPPED:
+		return "SKIPPED"
+	case DELAYED:
+		return "DELAYED"
+	case EXPIRED:
+		return "EXPIRED"
+	}
+	return "UNKNOWN"
+}
+
+// transitions lists the statuses a job may move to from each status
+var transitions = map[Status][]Status{
+	WAITING:       {STARTING, FAILED, CANCELLED, UNSCHEDULABLE, EXPIRED},
+	STARTING:      {WAITING, RUNNING, FAILED, CANCELLED},
+	RUNNING:       {WAITING, SUCCESS, FAILED, CANCELLED, TIMED_OUT},
+	UNSCHEDULABLE: {WAITING, FAILED, CANCELLED, EXPIRED},
+	BLOCKED:       {WAITING, FAILED, CANCELLED, SKIPPED, DELAYED, EXPIRED},
+	DELAYED:       {WAITING, CANCELLED, EXPIRED},
+}
+
+// CanTransition reports whether a job in status s may move to status to
+func (s Status) CanTransition(to Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Finished reports whether s is a status a job never leaves
+func (s Status) Finished() bool {
+	return len(transitions[s]) == 0
+}
+
+// Outcome decides whether a process which has finished succeeded
+func Outcome(exitCode int, signal string, errmsg string) Status {
+	if exitCode != 0 || signal != "" || errmsg != "" {
+		return FAILED
+	}
+	return SUCCESS
+}
+
+// Transition records when a job entered a status
+type Transition struct {
+	Status Status
+	Time   time.Time
+	Reason string // set where the job did not get there by itself, e.g. it was preempted
+}
+
+// RetryPolicy decides whether, and when, a job which failed is run again
+type RetryPolicy struct {
+	MaxAttempts        int           // runs including the first, 0 or 1 for no retries
+	BackoffBase        time.Duration // delay before the first retry, doubled for each one after
+	BackoffCap         time.Duration // longest delay between retries, 0 for MaxBackoff
+	Jitter             float64       // fraction of the delay to randomise by, 0 to 1
+	RetryableExitCodes []int         // exit codes worth retrying, empty for any failure
+	DifferentWorker    bool          // prefer a worker the job has not failed on yet
+}
+
+// Limits on retry policies, so a job is neither retried for ever nor waits so
+// long between retries its delay overflows
+const (
+	MaxRetryAttempts = 100
+	MaxBackoff       = 24 * time.Hour // longest delay between retries when the policy sets no cap
+)
+
+// ShouldRetry reports whether a job which ended as outcome on the given
+// attempt should be run again
+func (policy *RetryPolicy) ShouldRetry(attempt int, outcome Status, exitCode int) bool {
+	if attempt >= policy.MaxAttempts {
+		return false
+	}
+	if outcome != FAILED && outcome != TIMED_OUT {
+		return false
+	}
+	if len(policy.RetryableExitCodes) == 0 {
+		return true
+	}
+	for _, code := range policy.RetryableExitCodes {
+		if code == exitCode {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff returns how long to wait before running the job again after the
+// given attempt failed
+func (policy *RetryPolicy) Backoff(attempt int) time.Duration {
+	limit := MaxBackoff
+	if policy.BackoffCap > 0 && policy.BackoffCap < limit {
+		limit = policy.BackoffCap
+	}
+	delay := float64(policy.BackoffBase) * math.Pow(2, float64(attempt-1))
+	// clamped before the jitter too, as the doubling may have overflowed
+	delay = math.Min(delay, float64(limit))
+	delay += delay * policy.Jitter * (2*rand.Float64() - 1)
+	delay = math.Max(0, math.Min(delay, float64(limit)))
+	return time.Duration(delay)
+}
+
+// Dispatch is how many workers a job is run on
+type Dispatch int
+
+const (
+	DISPATCH_ONE Dispatch = iota // 0, any one worker
+	DISPATCH_ALL                 // 1, every matching worker
+	DISPATCH_N                   // 2, a number of distinct workers
 )
 
+func (d Dispatch) String() string {
+	switch d {
+	case DISPATCH_ONE:
+		return "one"
+	case DISPATCH_ALL:
+		return "all"
+	case DISPATCH_N:
+		return "N"
+	}
+	return "UNKNOWN"
+}
+
+// FailurePolicy is what happens to the jobs in a workflow which depend on a
+// job which did not succeed
+type FailurePolicy int
+
+const (
+	SKIP_DEPENDENTS FailurePolicy = iota // 0, they are SKIPPED
+	FAIL_DEPENDENTS                      // 1, they are FAILED
+)
+
+func (p FailurePolicy) String() string {
+	switch p {
+	case SKIP_DEPENDENTS:
+		return "skip"
+	case FAIL_DEPENDENTS:
+		return "fail"
+	}
+	return "UNKNOWN"
+}
+
+// Attempt records one run of a job on a worker
+type Attempt struct {
+	Number   int
+	Worker   string
+	Status   Status
+	ExitCode int
+	Signal   string
+	Error    string
+	Start    time.Time
+	End      time.Time
+
+	PreemptedBy int32 // the job the run was stopped to make room for, if any
+}
+
+// Resources is what a job needs from the worker it runs on
+type Resources struct {
+	CPUs   float64
+	Memory int64 // bytes
+	Disk   int64 // bytes
+}
+
 type Job struct {
-	Command string
-	Args    []string
-	Status  Status
+	ID            int32
+	Command       string
+	Args          []string
+	Env           map[string]string
+	WorkingDir    string
+	Timeout       time.Duration // how long the job may run for, 0 for ever
+	KillGrace     time.Duration // time between SIGTERM and SIGKILL, 0 for the worker's default
+	Labels        map[string]string
+	Selector      Selector // the workers the job may run on
+	Resources     Resources
+	Retry         RetryPolicy
+	Dispatch      Dispatch
+	Count         int     // how many workers a DISPATCH_N job runs on
+	Priority      int     // higher runs first, and may preempt lower
+	PriorityClass string  // the named priority the job was submitted with, if any
+	Tenant        string  // the team the job is run for
+	Parent        int32   // the job this one is a run of on a single worker, 0 if none
+	Pin           string  // the only worker the job may run on, if set
+	Children      []int32 // the runs of a job dispatched to more than one worker
+	Workflow      int32   // the workflow the job is part of, 0 if none
+	Name          string  // the job's name within its workflow
+	DependsOn     []int32 // jobs in the workflow which must succeed before this one runs
+	OnFailure     FailurePolicy
+	Schedule      int32 // the schedule which created the job, 0 if none
+	Status        Status
+	Worker        string
+	History       []Transition
+	ExitCode      int
+	Signal        string // set if the process was killed by a signal
+	Error         string // set if the process could not be run at all
+	Attempts      []Attempt
+	NotBefore     time.Time     // a retry waits until then before being sent out
+	RunAt         time.Time     // the job is held DELAYED until then, if set
+	TTL           time.Duration // how long the job may wait to be sent out once WAITING before it EXPIRES, 0 for ever
+	LeaseEnd      time.Time     // a job leased by a worker in pull mode is lost if not renewed by then
+
+	CancelRequested bool
+	PreemptedBy     int32 // the job this one is being stopped to make room for
+}
+
+// Attempt returns the number of the job's current (or next) run
+func (job *Job) Attempt() int {
+	return len(job.Attempts) + 1
+}
+
+// Tries returns how many of the job's runs count towards its retry policy,
+// leaving out those which were preempted
+func (job *Job) Tries() int {
+	tries := 0
+	for _, attempt := range job.Attempts {
+		if attempt.PreemptedBy == 0 {
+			tries += 1
+		}
+	}
+	return tries
+}
+
+// ExpiresAt returns when the job EXPIRES unless it has been sent out by then,
+// which is its TTL after it first became WAITING, or the zero time if it never
+// does or is not due yet
+func (job *Job) ExpiresAt() time.Time {
+	if job.TTL == 0 {
+		return time.Time{}
+	}
+	for _, transition := range job.History {
+		if transition.Status == WAITING {
+			return transition.Time.Add(job.TTL)
+		}
+	}
+	// still BLOCKED or DELAYED
+	return time.Time{}
+}
+
+// FanOut reports whether the job is run on more than one worker, as a child
+// job on each of them
+func (job *Job) FanOut() bool {
+	return job.Dispatch != DISPATCH_ONE
+}
+
+// StatusTime returns when the job last entered a status, or the zero time if
+// it never has
+func (job *Job) StatusTime(stat Status) time.Time {
+	for i := len(job.History) - 1; i >= 0; i-- {
+		if job.History[i].Status == stat {
+			return job.History[i].Time
+		}
+	}
+	return time.Time{}
+}
+
+// SetStatus moves the job to a new status, recording when it happened
+func (job *Job) SetStatus(to Status, when time.Time) error {
+	if !job.Status.CanTransition(to) {
+		return fmt.Errorf("job %d cannot go from %v to %v", job.ID, job.Status, to)
+	}
+	job.Status = to
+	job.History = append(job.History, Transition{Status: to, Time: when})
+	return nil
+}
+
+// SetStatusFor is SetStatus for a status change the job did not bring about
+// itself, recording why it happened
+func (job *Job) SetStatusFor(to Status, when time.Time, reason string) error {
+	err := job.SetStatus(to, when)
+	if err == nil {
+		job.History[len(job.History)-1].Reason = reason
+	}
+	return err
 }
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{"first retry", RetryPolicy{BackoffBase: time.Second}, 1, time.Second},
+		{"doubles", RetryPolicy{BackoffBase: time.Second}, 4, 8 * time.Second},
+		{"capped", RetryPolicy{BackoffBase: time.Second, BackoffCap: 5 * time.Second}, 4, 5 * time.Second},
+		{"no cap", RetryPolicy{BackoffBase: time.Second}, 20, MaxBackoff},
+		{"would overflow", RetryPolicy{BackoffBase: time.Second}, 35, MaxBackoff},
+		{"far past overflow", RetryPolicy{BackoffBase: time.Hour}, 2000, MaxBackoff},
+		{"cap above the limit", RetryPolicy{BackoffBase: time.Second, BackoffCap: 100 * MaxBackoff}, 60, MaxBackoff},
+	}
+	for _, test := range tests {
+		got := test.policy.Backoff(test.attempt)
+		if got != test.want {
+			t.Errorf("%s: Backoff(%d) = %v, want %v", test.name, test.attempt, got, test.want)
+		}
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{"below the ceiling", RetryPolicy{BackoffBase: time.Second, Jitter: 0.5}, 4, 4 * time.Second, 12 * time.Second},
+		{"at the cap", RetryPolicy{BackoffBase: time.Second, BackoffCap: 5 * time.Second, Jitter: 0.5}, 4, 2500 * time.Millisecond, 5 * time.Second},
+		{"at the limit", RetryPolicy{BackoffBase: time.Hour, Jitter: 1}, 60, 0, MaxBackoff},
+	}
+	for _, test := range tests {
+		for i := 0; i < 1000; i++ {
+			got := test.policy.Backoff(test.attempt)
+			if got < test.min || got > test.max {
+				t.Fatalf("%s: Backoff(%d) = %v, want %v to %v", test.name, test.attempt, got, test.min, test.max)
+			}
+		}
+	}
+}
+
+func TestExpiresAt(t *testing.T) {
+	submitted := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	due := submitted.Add(time.Hour)
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		history []Status // one an hour from submission
+		want    time.Time
+	}{
+		{"no TTL", 0, []Status{WAITING}, time.Time{}},
+		{"waiting", time.Minute, []Status{WAITING}, submitted.Add(time.Minute)},
+		{"blocked", time.Minute, []Status{BLOCKED}, time.Time{}},
+		{"delayed", time.Minute, []Status{DELAYED}, time.Time{}},
+		{"released", time.Minute, []Status{BLOCKED, WAITING}, due.Add(time.Minute)},
+		{"bounced", time.Minute, []Status{DELAYED, WAITING, UNSCHEDULABLE, WAITING}, due.Add(time.Minute)},
+	}
+	for _, test := range tests {
+		job := &Job{TTL: test.ttl}
+		for i, stat := range test.history {
+			job.History = append(job.History, Transition{Status: stat, Time: submitted.Add(time.Duration(i) * time.Hour)})
+		}
+		got := job.ExpiresAt()
+		if !got.Equal(test.want) {
+			t.Errorf("%s: ExpiresAt() = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
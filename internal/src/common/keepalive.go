@@ -0,0 +1,22 @@
+package common
+
+import (
+	"time"
+
+	"google.golang.org/grpc/keepalive"
+)
+
+// ClientKeepalive pings idle connections between the commander and its
+// workers, so a peer which has gone away is noticed before a message fails
+var ClientKeepalive = keepalive.ClientParameters{
+	Time:                30 * time.Second,
+	Timeout:             10 * time.Second,
+	PermitWithoutStream: true,
+}
+
+// ServerKeepalive lets clients ping as often as ClientKeepalive does, the
+// gRPC default would hang up on them for pinging too much
+var ServerKeepalive = keepalive.EnforcementPolicy{
+	MinTime:             20 * time.Second,
+	PermitWithoutStream: true,
+}
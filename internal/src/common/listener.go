@@ -19,3 +19,20 @@ func GetOutboundIP(server string) string {
 
 	return localAddr.IP.String()
 }
+
+// Get every address of this machine, apart from loopback ones
+func GetLocalAddresses() []string {
+	var addresses []string
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		log.Printf("ERROR: %v\n", err)
+		return nil
+	}
+	for _, addr := range addrs {
+		ipnet, ok := addr.(*net.IPNet)
+		if ok && !ipnet.IP.IsLoopback() {
+			addresses = append(addresses, ipnet.IP.String())
+		}
+	}
+	return addresses
+}
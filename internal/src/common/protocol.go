@@ -0,0 +1,44 @@
+package common
+
+// Versions of the protocol spoken between the commander and its workers
+const (
+	// Jobs sent gob encoded, results returned by the Work RPC
+	PROTOCOL_V1 int32 = 1
+	// Jobs sent as protobuf, results reported through the JobStatusService
+	PROTOCOL_V2 int32 = 2
+	// Heartbeats also served on the work port, so the commander needs only
+	// one connection to each worker
+	PROTOCOL_V3 int32 = 3
+)
+
+// Optional features a commander or worker may support on top of the protocol
+const (
+	FEATURE_CANCEL        = "cancel"
+	FEATURE_OUTPUT_STREAM = "output-stream"
+)
+
+// NegotiateVersion returns the highest version in offered which lies between
+// min and max, or 0 if there is none
+func NegotiateVersion(offered []int32, min int32, max int32) int32 {
+	var best int32
+	for _, version := range offered {
+		if version >= min && version <= max && version > best {
+			best = version
+		}
+	}
+	return best
+}
+
+// CommonFeatures returns the features found in both a and b
+func CommonFeatures(a []string, b []string) []string {
+	var common []string
+	for _, feature := range a {
+		for _, other := range b {
+			if feature == other {
+				common = append(common, feature)
+				break
+			}
+		}
+	}
+	return common
+}
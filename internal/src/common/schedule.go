@@ -0,0 +1,46 @@
+package common
+
+import (
+	"time"
+)
+
+// OverlapPolicy is what a schedule does when a run is due while the job it
+// created last time is still going
+type OverlapPolicy int
+
+const (
+	OVERLAP_ALLOW   OverlapPolicy = iota // 0, create the job anyway
+	OVERLAP_SKIP                         // 1, leave this run out
+	OVERLAP_REPLACE                      // 2, cancel the job still going and create a new one
+)
+
+func (p OverlapPolicy) String() string {
+	switch p {
+	case OVERLAP_ALLOW:
+		return "allow"
+	case OVERLAP_SKIP:
+		return "skip"
+	case OVERLAP_REPLACE:
+		return "replace"
+	}
+	return "UNKNOWN"
+}
+
+// Schedule creates a job from a template each time its cron expression fires
+type Schedule struct {
+	ID       int32
+	Name     string
+	Cron     string
+	TimeZone string // IANA time zone the expression is read in, UTC if empty
+	Overlap  OverlapPolicy
+	CatchUp  bool // run once straight away if runs were missed while the Commander was down
+	Job      *Job // the template for each run
+	Created  time.Time
+	LastRun  time.Time   // when the last run was due, whether a job was created for it or not
+	NextRun  time.Time   // zero if the expression never fires again
+	Jobs     []int32     // the jobs created for the most recent runs
+	Skipped  int         // runs left out as the last job was still going
+	Missed   int         // runs which fell due but were never made, e.g. while the Commander was down
+	MissedAt []time.Time // when the most recent missed runs were due
+	Removed  bool
+}
@@ -0,0 +1,193 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Operator is how a Requirement compares a label with its values
+type Operator int
+
+const (
+	EQUALS     Operator = iota // 0
+	NOT_EQUALS                 // 1
+	IN                         // 2
+	NOT_IN                     // 3
+	EXISTS                     // 4
+	NOT_EXISTS                 // 5
+)
+
+// Requirement is a condition on one of a worker's labels
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Selector picks the workers a job may run on, a worker must meet every
+// requirement. An empty selector matches every worker.
+type Selector []Requirement
+
+// Matches reports whether a worker with the given labels meets r. As with
+// Kubernetes label selectors, != and notin match workers without the label.
+func (r Requirement) Matches(labels map[string]string) bool {
+	value, found := labels[r.Key]
+	switch r.Operator {
+	case EQUALS, IN:
+		return found && r.has(value)
+	case NOT_EQUALS, NOT_IN:
+		return !found || !r.has(value)
+	case EXISTS:
+		return found
+	case NOT_EXISTS:
+		return !found
+	}
+	return false
+}
+
+func (r Requirement) has(value string) bool {
+	for _, v := range r.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks r has the number of values its operator needs
+func (r Requirement) Validate() error {
+	if r.Key == "" {
+		return fmt.Errorf("label selector requirement has no key")
+	}
+	switch r.Operator {
+	case EQUALS, NOT_EQUALS:
+		if len(r.Values) != 1 {
+			return fmt.Errorf("%s needs exactly one value", r)
+		}
+	case IN, NOT_IN:
+		if len(r.Values) == 0 {
+			return fmt.Errorf("%s needs at least one value", r)
+		}
+	case EXISTS, NOT_EXISTS:
+		if len(r.Values) != 0 {
+			return fmt.Errorf("%s takes no values", r)
+		}
+	default:
+		return fmt.Errorf("label selector requirement on %s has an unknown operator", r.Key)
+	}
+	return nil
+}
+
+func (r Requirement) String() string {
+	switch r.Operator {
+	case EQUALS:
+		return fmt.Sprintf("%s=%s", r.Key, strings.Join(r.Values, ","))
+	case NOT_EQUALS:
+		return fmt.Sprintf("%s!=%s", r.Key, strings.Join(r.Values, ","))
+	case IN:
+		return fmt.Sprintf("%s in (%s)", r.Key, strings.Join(r.Values, ","))
+	case NOT_IN:
+		return fmt.Sprintf("%s notin (%s)", r.Key, strings.Join(r.Values, ","))
+	case EXISTS:
+		return r.Key
+	case NOT_EXISTS:
+		return "!" + r.Key
+	}
+	return r.Key + " ?"
+}
+
+// Matches reports whether a worker with the given labels meets every
+// requirement of sel
+func (sel Selector) Matches(labels map[string]string) bool {
+	for _, r := range sel {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func (sel Selector) String() string {
+	var requirements []string
+	for _, r := range sel {
+		requirements = append(requirements, r.String())
+	}
+	return strings.Join(requirements, ",")
+}
+
+// ParseSelector parses a comma separated list of requirements, each one of
+// key=value, key!=value, key in (a,b), key notin (a,b), key or !key
+func ParseSelector(text string) (Selector, error) {
+	var sel Selector
+	for _, part := range splitRequirements(text) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		r, err := parseRequirement(part)
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, r)
+	}
+	return sel, nil
+}
+
+// splitRequirements splits on the commas which are not inside parentheses
+func splitRequirements(text string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range text {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, text[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, text[start:])
+}
+
+func parseRequirement(text string) (Requirement, error) {
+	var r Requirement
+	if fields := strings.Fields(text); len(fields) >= 2 && (fields[1] == "in" || fields[1] == "notin") {
+		r = Requirement{Key: fields[0], Operator: IN}
+		if fields[1] == "notin" {
+			r.Operator = NOT_IN
+		}
+		set := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text[len(fields[0]):]), fields[1]))
+		if !strings.HasPrefix(set, "(") || !strings.HasSuffix(set, ")") {
+			return r, fmt.Errorf("invalid label selector requirement %q, expected a set in parentheses", text)
+		}
+		for _, value := range strings.Split(set[1:len(set)-1], ",") {
+			value = strings.TrimSpace(value)
+			if value != "" {
+				r.Values = append(r.Values, value)
+			}
+		}
+	} else if i := strings.Index(text, "!="); i >= 0 {
+		r = Requirement{Key: text[:i], Operator: NOT_EQUALS, Values: []string{text[i+2:]}}
+	} else if i := strings.Index(text, "=="); i >= 0 {
+		r = Requirement{Key: text[:i], Operator: EQUALS, Values: []string{text[i+2:]}}
+	} else if i := strings.Index(text, "="); i >= 0 {
+		r = Requirement{Key: text[:i], Operator: EQUALS, Values: []string{text[i+1:]}}
+	} else if strings.HasPrefix(text, "!") {
+		r = Requirement{Key: strings.TrimSpace(text[1:]), Operator: NOT_EXISTS}
+	} else {
+		r = Requirement{Key: text, Operator: EXISTS}
+	}
+
+	r.Key = strings.TrimSpace(r.Key)
+	for i := range r.Values {
+		r.Values[i] = strings.TrimSpace(r.Values[i])
+	}
+	if strings.ContainsAny(r.Key, " ()!=") {
+		return r, fmt.Errorf("invalid label selector requirement %q", text)
+	}
+	return r, r.Validate()
+}
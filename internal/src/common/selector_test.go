@@ -0,0 +1,93 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		text string
+		want Selector
+		ok   bool
+	}{
+		{"", nil, true},
+		{"os=linux", Selector{{"os", EQUALS, []string{"linux"}}}, true},
+		{"os==linux", Selector{{"os", EQUALS, []string{"linux"}}}, true},
+		{" os = linux ", Selector{{"os", EQUALS, []string{"linux"}}}, true},
+		{"os!=windows", Selector{{"os", NOT_EQUALS, []string{"windows"}}}, true},
+		{"zone in (a, b)", Selector{{"zone", IN, []string{"a", "b"}}}, true},
+		{"zone notin (a,b,c)", Selector{{"zone", NOT_IN, []string{"a", "b", "c"}}}, true},
+		{"gpu", Selector{{"gpu", EXISTS, nil}}, true},
+		{"!gpu", Selector{{"gpu", NOT_EXISTS, nil}}, true},
+		{"os=linux,zone in (a,b),!gpu", Selector{
+			{"os", EQUALS, []string{"linux"}},
+			{"zone", IN, []string{"a", "b"}},
+			{"gpu", NOT_EXISTS, nil},
+		}, true},
+		{"os=linux,,gpu", Selector{{"os", EQUALS, []string{"linux"}}, {"gpu", EXISTS, nil}}, true},
+		{"zone in ()", nil, false},
+		{"zone in a,b", nil, false},
+		{"=linux", nil, false},
+		{"my key=x", nil, false},
+		{"(zone)", nil, false},
+	}
+	for _, test := range tests {
+		got, err := ParseSelector(test.text)
+		if (err == nil) != test.ok {
+			t.Errorf("ParseSelector(%q) = %v, want ok %v", test.text, err, test.ok)
+			continue
+		}
+		if test.ok && !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseSelector(%q) = %#v, want %#v", test.text, got, test.want)
+		}
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{"os": "linux", "zone": "a"}
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"", true},
+		{"os=linux", true},
+		{"os=windows", false},
+		{"os!=windows", true},
+		{"os!=linux", false},
+		// as with Kubernetes, != and notin match workers without the label
+		{"gpu!=yes", true},
+		{"gpu notin (yes)", true},
+		{"zone in (a,b)", true},
+		{"zone in (b,c)", false},
+		{"zone notin (a,b)", false},
+		{"gpu in (yes)", false},
+		{"zone", true},
+		{"gpu", false},
+		{"!gpu", true},
+		{"!zone", false},
+		{"os=linux,zone=a", true},
+		{"os=linux,zone=b", false},
+	}
+	for _, test := range tests {
+		sel, err := ParseSelector(test.text)
+		if err != nil {
+			t.Fatalf("ParseSelector(%q): %v", test.text, err)
+		}
+		if got := sel.Matches(labels); got != test.want {
+			t.Errorf("%q matches %v = %v, want %v", test.text, labels, got, test.want)
+		}
+	}
+}
+
+func TestSelectorString(t *testing.T) {
+	for _, text := range []string{"os=linux", "os!=linux", "zone in (a,b)", "zone notin (a)", "gpu", "!gpu", "os=linux,!gpu"} {
+		sel, err := ParseSelector(text)
+		if err != nil {
+			t.Fatalf("ParseSelector(%q): %v", text, err)
+		}
+		if got := sel.String(); got != text {
+			t.Errorf("ParseSelector(%q).String() = %q", text, got)
+		}
+	}
+}
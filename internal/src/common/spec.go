@@ -0,0 +1,70 @@
+package common
+
+import (
+	"pbMessages"
+	"time"
+)
+
+// JobFromSpec builds a job from its protocol buffers definition
+func JobFromSpec(spec *pbMessages.Job) *Job {
+	return &Job{
+		Command:    spec.GetCommand(),
+		Args:       spec.GetArgs(),
+		Env:        spec.GetEnv(),
+		WorkingDir: spec.GetWorkingDir(),
+		Timeout:    time.Duration(spec.GetTimeout()),
+		KillGrace:  time.Duration(spec.GetKillGrace()),
+		Labels:     spec.GetLabels(),
+		Selector:   SelectorFromSpec(spec.GetSelector()),
+		Resources: Resources{
+			CPUs:   spec.GetResources().GetCpus(),
+			Memory: spec.GetResources().GetMemory(),
+			Disk:   spec.GetResources().GetDisk(),
+		},
+	}
+}
+
+// Spec returns the protocol buffers definition of a job
+func (job *Job) Spec() *pbMessages.Job {
+	return &pbMessages.Job{
+		Command:    job.Command,
+		Args:       job.Args,
+		Env:        job.Env,
+		WorkingDir: job.WorkingDir,
+		Timeout:    int64(job.Timeout),
+		KillGrace:  int64(job.KillGrace),
+		Labels:     job.Labels,
+		Selector:   job.Selector.Spec(),
+		Resources: &pbMessages.Resources{
+			Cpus:   job.Resources.CPUs,
+			Memory: job.Resources.Memory,
+			Disk:   job.Resources.Disk,
+		},
+	}
+}
+
+// SelectorFromSpec builds a selector from its protocol buffers definition
+func SelectorFromSpec(spec []*pbMessages.LabelRequirement) Selector {
+	var sel Selector
+	for _, r := range spec {
+		sel = append(sel, Requirement{
+			Key:      r.GetKey(),
+			Operator: Operator(r.GetOperator()),
+			Values:   r.GetValues(),
+		})
+	}
+	return sel
+}
+
+// Spec returns the protocol buffers definition of a selector
+func (sel Selector) Spec() []*pbMessages.LabelRequirement {
+	var spec []*pbMessages.LabelRequirement
+	for _, r := range sel {
+		spec = append(spec, &pbMessages.LabelRequirement{
+			Key:      r.Key,
+			Operator: pbMessages.LabelOperator(r.Operator),
+			Values:   r.Values,
+		})
+	}
+	return spec
+}
@@ -24,13 +24,169 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type LabelOperator int32
+
+const (
+	LabelOperator_EQUALS     LabelOperator = 0
+	LabelOperator_NOT_EQUALS LabelOperator = 1
+	LabelOperator_IN         LabelOperator = 2
+	LabelOperator_NOT_IN     LabelOperator = 3
+	LabelOperator_EXISTS     LabelOperator = 4
+	LabelOperator_NOT_EXISTS LabelOperator = 5
+)
+
+var LabelOperator_name = map[int32]string{
+	0: "EQUALS",
+	1: "NOT_EQUALS",
+	2: "IN",
+	3: "NOT_IN",
+	4: "EXISTS",
+	5: "NOT_EXISTS",
+}
+
+var LabelOperator_value = map[string]int32{
+	"EQUALS":     0,
+	"NOT_EQUALS": 1,
+	"IN":         2,
+	"NOT_IN":     3,
+	"EXISTS":     4,
+	"NOT_EXISTS": 5,
+}
+
+func (x LabelOperator) String() string {
+	return proto.EnumName(LabelOperator_name, int32(x))
+}
+
+func (LabelOperator) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{0}
+}
+
+type DispatchMode int32
+
+const (
+	DispatchMode_DISPATCH_ONE DispatchMode = 0
+	DispatchMode_DISPATCH_ALL DispatchMode = 1
+	DispatchMode_DISPATCH_N   DispatchMode = 2
+)
+
+var DispatchMode_name = map[int32]string{
+	0: "DISPATCH_ONE",
+	1: "DISPATCH_ALL",
+	2: "DISPATCH_N",
+}
+
+var DispatchMode_value = map[string]int32{
+	"DISPATCH_ONE": 0,
+	"DISPATCH_ALL": 1,
+	"DISPATCH_N":   2,
+}
+
+func (x DispatchMode) String() string {
+	return proto.EnumName(DispatchMode_name, int32(x))
+}
+
+func (DispatchMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{1}
+}
+
+// What happens to the jobs in a workflow which depend on a job which did not
+// succeed
+type FailurePolicy int32
+
+const (
+	FailurePolicy_SKIP_DEPENDENTS FailurePolicy = 0
+	FailurePolicy_FAIL_DEPENDENTS FailurePolicy = 1
+)
+
+var FailurePolicy_name = map[int32]string{
+	0: "SKIP_DEPENDENTS",
+	1: "FAIL_DEPENDENTS",
+}
+
+var FailurePolicy_value = map[string]int32{
+	"SKIP_DEPENDENTS": 0,
+	"FAIL_DEPENDENTS": 1,
+}
+
+func (x FailurePolicy) String() string {
+	return proto.EnumName(FailurePolicy_name, int32(x))
+}
+
+func (FailurePolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{2}
+}
+
+// Stdout & Errout (requestStdOut/responseStdOut), served by workers for the
+// jobs they run and proxied by the commander
+type OutputStream int32
+
+const (
+	OutputStream_STDOUT OutputStream = 0
+	OutputStream_STDERR OutputStream = 1
+)
+
+var OutputStream_name = map[int32]string{
+	0: "STDOUT",
+	1: "STDERR",
+}
+
+var OutputStream_value = map[string]int32{
+	"STDOUT": 0,
+	"STDERR": 1,
+}
+
+func (x OutputStream) String() string {
+	return proto.EnumName(OutputStream_name, int32(x))
+}
+
+func (OutputStream) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{3}
+}
+
+// Schedule service (createScheduleRequest/scheduleInfo, getScheduleRequest/scheduleInfo,
+// listSchedulesRequest/listSchedulesResponse, removeScheduleRequest/removeScheduleResponse)
+type OverlapPolicy int32
+
+const (
+	OverlapPolicy_OVERLAP_ALLOW   OverlapPolicy = 0
+	OverlapPolicy_OVERLAP_SKIP    OverlapPolicy = 1
+	OverlapPolicy_OVERLAP_REPLACE OverlapPolicy = 2
+)
+
+var OverlapPolicy_name = map[int32]string{
+	0: "OVERLAP_ALLOW",
+	1: "OVERLAP_SKIP",
+	2: "OVERLAP_REPLACE",
+}
+
+var OverlapPolicy_value = map[string]int32{
+	"OVERLAP_ALLOW":   0,
+	"OVERLAP_SKIP":    1,
+	"OVERLAP_REPLACE": 2,
+}
+
+func (x OverlapPolicy) String() string {
+	return proto.EnumName(OverlapPolicy_name, int32(x))
+}
+
+func (OverlapPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{4}
+}
+
 type HelloRequest struct {
-	Version              int32    `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
-	Ip                   string   `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
-	Fqdn                 string   `protobuf:"bytes,3,opt,name=fqdn,proto3" json:"fqdn,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Version              int32             `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Ip                   string            `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Fqdn                 string            `protobuf:"bytes,3,opt,name=fqdn,proto3" json:"fqdn,omitempty"`
+	SupportedVersions    []int32           `protobuf:"varint,4,rep,packed,name=supportedVersions,proto3" json:"supportedVersions,omitempty"`
+	Features             []string          `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`
+	Mode                 string            `protobuf:"bytes,6,opt,name=mode,proto3" json:"mode,omitempty"`
+	Id                   string            `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
+	Addresses            []string          `protobuf:"bytes,8,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Resources            *WorkerResources  `protobuf:"bytes,10,opt,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *HelloRequest) Reset()         { *m = HelloRequest{} }
@@ -79,8 +235,58 @@ func (m *HelloRequest) GetFqdn() string {
 	return ""
 }
 
+func (m *HelloRequest) GetSupportedVersions() []int32 {
+	if m != nil {
+		return m.SupportedVersions
+	}
+	return nil
+}
+
+func (m *HelloRequest) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *HelloRequest) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *HelloRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *HelloRequest) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *HelloRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *HelloRequest) GetResources() *WorkerResources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
 type HelloResponse struct {
 	Version              int32    `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Features             []string `protobuf:"bytes,2,rep,name=features,proto3" json:"features,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -118,6 +324,13 @@ func (m *HelloResponse) GetVersion() int32 {
 	return 0
 }
 
+func (m *HelloResponse) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
 // Heartbeat service (ping/pong)
 type Ping struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -159,10 +372,11 @@ func (m *Ping) GetName() string {
 }
 
 type Pong struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name                 string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Resources            *WorkerResources `protobuf:"bytes,2,opt,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *Pong) Reset()         { *m = Pong{} }
@@ -197,447 +411,5110 @@ func (m *Pong) GetName() string {
 	return ""
 }
 
-// Work service (workRequest/workResponse)
-type WorkRequest struct {
-	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
-	Job                  []byte   `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+func (m *Pong) GetResources() *WorkerResources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+// What a worker has to run jobs with. Sizes are 0 if the worker cannot tell.
+type WorkerResources struct {
+	Cpus                 int32    `protobuf:"varint,1,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	TotalMemory          int64    `protobuf:"varint,2,opt,name=totalMemory,proto3" json:"totalMemory,omitempty"`
+	FreeMemory           int64    `protobuf:"varint,3,opt,name=freeMemory,proto3" json:"freeMemory,omitempty"`
+	TotalDisk            int64    `protobuf:"varint,4,opt,name=totalDisk,proto3" json:"totalDisk,omitempty"`
+	FreeDisk             int64    `protobuf:"varint,5,opt,name=freeDisk,proto3" json:"freeDisk,omitempty"`
+	Os                   string   `protobuf:"bytes,6,opt,name=os,proto3" json:"os,omitempty"`
+	Arch                 string   `protobuf:"bytes,7,opt,name=arch,proto3" json:"arch,omitempty"`
+	Slots                int32    `protobuf:"varint,8,opt,name=slots,proto3" json:"slots,omitempty"`
+	SlotsUsed            int32    `protobuf:"varint,9,opt,name=slotsUsed,proto3" json:"slotsUsed,omitempty"`
+	Queued               int32    `protobuf:"varint,10,opt,name=queued,proto3" json:"queued,omitempty"`
+	QueueSize            int32    `protobuf:"varint,11,opt,name=queueSize,proto3" json:"queueSize,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *WorkRequest) Reset()         { *m = WorkRequest{} }
-func (m *WorkRequest) String() string { return proto.CompactTextString(m) }
-func (*WorkRequest) ProtoMessage()    {}
-func (*WorkRequest) Descriptor() ([]byte, []int) {
+func (m *WorkerResources) Reset()         { *m = WorkerResources{} }
+func (m *WorkerResources) String() string { return proto.CompactTextString(m) }
+func (*WorkerResources) ProtoMessage()    {}
+func (*WorkerResources) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d3b30b43b8942386, []int{4}
 }
 
-func (m *WorkRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_WorkRequest.Unmarshal(m, b)
+func (m *WorkerResources) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkerResources.Unmarshal(m, b)
 }
-func (m *WorkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_WorkRequest.Marshal(b, m, deterministic)
+func (m *WorkerResources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkerResources.Marshal(b, m, deterministic)
 }
-func (m *WorkRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WorkRequest.Merge(m, src)
+func (m *WorkerResources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkerResources.Merge(m, src)
 }
-func (m *WorkRequest) XXX_Size() int {
-	return xxx_messageInfo_WorkRequest.Size(m)
+func (m *WorkerResources) XXX_Size() int {
+	return xxx_messageInfo_WorkerResources.Size(m)
 }
-func (m *WorkRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_WorkRequest.DiscardUnknown(m)
+func (m *WorkerResources) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkerResources.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_WorkRequest proto.InternalMessageInfo
+var xxx_messageInfo_WorkerResources proto.InternalMessageInfo
 
-func (m *WorkRequest) GetJobID() int32 {
+func (m *WorkerResources) GetCpus() int32 {
 	if m != nil {
-		return m.JobID
+		return m.Cpus
 	}
 	return 0
 }
 
-func (m *WorkRequest) GetJob() []byte {
+func (m *WorkerResources) GetTotalMemory() int64 {
 	if m != nil {
-		return m.Job
+		return m.TotalMemory
 	}
-	return nil
+	return 0
 }
 
-type WorkResponse struct {
-	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
-	Output               string   `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *WorkerResources) GetFreeMemory() int64 {
+	if m != nil {
+		return m.FreeMemory
+	}
+	return 0
 }
 
-func (m *WorkResponse) Reset()         { *m = WorkResponse{} }
-func (m *WorkResponse) String() string { return proto.CompactTextString(m) }
-func (*WorkResponse) ProtoMessage()    {}
-func (*WorkResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3b30b43b8942386, []int{5}
+func (m *WorkerResources) GetTotalDisk() int64 {
+	if m != nil {
+		return m.TotalDisk
+	}
+	return 0
 }
 
-func (m *WorkResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_WorkResponse.Unmarshal(m, b)
-}
-func (m *WorkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_WorkResponse.Marshal(b, m, deterministic)
+func (m *WorkerResources) GetFreeDisk() int64 {
+	if m != nil {
+		return m.FreeDisk
+	}
+	return 0
 }
-func (m *WorkResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WorkResponse.Merge(m, src)
+
+func (m *WorkerResources) GetOs() string {
+	if m != nil {
+		return m.Os
+	}
+	return ""
 }
-func (m *WorkResponse) XXX_Size() int {
-	return xxx_messageInfo_WorkResponse.Size(m)
+
+func (m *WorkerResources) GetArch() string {
+	if m != nil {
+		return m.Arch
+	}
+	return ""
 }
-func (m *WorkResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_WorkResponse.DiscardUnknown(m)
+
+func (m *WorkerResources) GetSlots() int32 {
+	if m != nil {
+		return m.Slots
+	}
+	return 0
 }
 
-var xxx_messageInfo_WorkResponse proto.InternalMessageInfo
+func (m *WorkerResources) GetSlotsUsed() int32 {
+	if m != nil {
+		return m.SlotsUsed
+	}
+	return 0
+}
 
-func (m *WorkResponse) GetJobID() int32 {
+func (m *WorkerResources) GetQueued() int32 {
 	if m != nil {
-		return m.JobID
+		return m.Queued
 	}
 	return 0
 }
 
-func (m *WorkResponse) GetOutput() string {
+func (m *WorkerResources) GetQueueSize() int32 {
 	if m != nil {
-		return m.Output
+		return m.QueueSize
 	}
-	return ""
+	return 0
 }
 
-// Stdout & Errout (requestStdOut/responseStdOut)
-type RequestStdOut struct {
-	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+// Job definition, as submitted to the commander and sent on to workers
+type Resources struct {
+	Cpus                 float64  `protobuf:"fixed64,1,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	Memory               int64    `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	Disk                 int64    `protobuf:"varint,3,opt,name=disk,proto3" json:"disk,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RequestStdOut) Reset()         { *m = RequestStdOut{} }
-func (m *RequestStdOut) String() string { return proto.CompactTextString(m) }
-func (*RequestStdOut) ProtoMessage()    {}
-func (*RequestStdOut) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3b30b43b8942386, []int{6}
+func (m *Resources) Reset()         { *m = Resources{} }
+func (m *Resources) String() string { return proto.CompactTextString(m) }
+func (*Resources) ProtoMessage()    {}
+func (*Resources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{5}
 }
 
-func (m *RequestStdOut) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RequestStdOut.Unmarshal(m, b)
+func (m *Resources) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Resources.Unmarshal(m, b)
 }
-func (m *RequestStdOut) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RequestStdOut.Marshal(b, m, deterministic)
+func (m *Resources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Resources.Marshal(b, m, deterministic)
 }
-func (m *RequestStdOut) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RequestStdOut.Merge(m, src)
+func (m *Resources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Resources.Merge(m, src)
 }
-func (m *RequestStdOut) XXX_Size() int {
-	return xxx_messageInfo_RequestStdOut.Size(m)
+func (m *Resources) XXX_Size() int {
+	return xxx_messageInfo_Resources.Size(m)
 }
-func (m *RequestStdOut) XXX_DiscardUnknown() {
-	xxx_messageInfo_RequestStdOut.DiscardUnknown(m)
+func (m *Resources) XXX_DiscardUnknown() {
+	xxx_messageInfo_Resources.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RequestStdOut proto.InternalMessageInfo
+var xxx_messageInfo_Resources proto.InternalMessageInfo
 
-func (m *RequestStdOut) GetJobID() int32 {
+func (m *Resources) GetCpus() float64 {
 	if m != nil {
-		return m.JobID
+		return m.Cpus
 	}
 	return 0
 }
 
-type ResponseStdOut struct {
-	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
-	Data                 string   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Resources) GetMemory() int64 {
+	if m != nil {
+		return m.Memory
+	}
+	return 0
 }
 
-func (m *ResponseStdOut) Reset()         { *m = ResponseStdOut{} }
-func (m *ResponseStdOut) String() string { return proto.CompactTextString(m) }
-func (*ResponseStdOut) ProtoMessage()    {}
-func (*ResponseStdOut) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3b30b43b8942386, []int{7}
+func (m *Resources) GetDisk() int64 {
+	if m != nil {
+		return m.Disk
+	}
+	return 0
 }
 
-func (m *ResponseStdOut) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ResponseStdOut.Unmarshal(m, b)
+type LabelRequirement struct {
+	Key                  string        `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Operator             LabelOperator `protobuf:"varint,2,opt,name=operator,proto3,enum=messages.LabelOperator" json:"operator,omitempty"`
+	Values               []string      `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
-func (m *ResponseStdOut) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ResponseStdOut.Marshal(b, m, deterministic)
+
+func (m *LabelRequirement) Reset()         { *m = LabelRequirement{} }
+func (m *LabelRequirement) String() string { return proto.CompactTextString(m) }
+func (*LabelRequirement) ProtoMessage()    {}
+func (*LabelRequirement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{6}
 }
-func (m *ResponseStdOut) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseStdOut.Merge(m, src)
+
+func (m *LabelRequirement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelRequirement.Unmarshal(m, b)
 }
-func (m *ResponseStdOut) XXX_Size() int {
-	return xxx_messageInfo_ResponseStdOut.Size(m)
+func (m *LabelRequirement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelRequirement.Marshal(b, m, deterministic)
 }
-func (m *ResponseStdOut) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseStdOut.DiscardUnknown(m)
+func (m *LabelRequirement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelRequirement.Merge(m, src)
+}
+func (m *LabelRequirement) XXX_Size() int {
+	return xxx_messageInfo_LabelRequirement.Size(m)
+}
+func (m *LabelRequirement) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelRequirement.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResponseStdOut proto.InternalMessageInfo
+var xxx_messageInfo_LabelRequirement proto.InternalMessageInfo
 
-func (m *ResponseStdOut) GetJobID() int32 {
+func (m *LabelRequirement) GetKey() string {
 	if m != nil {
-		return m.JobID
+		return m.Key
 	}
-	return 0
+	return ""
 }
 
-func (m *ResponseStdOut) GetData() string {
+func (m *LabelRequirement) GetOperator() LabelOperator {
 	if m != nil {
-		return m.Data
+		return m.Operator
 	}
-	return ""
+	return LabelOperator_EQUALS
 }
 
-func init() {
-	proto.RegisterType((*HelloRequest)(nil), "messages.helloRequest")
-	proto.RegisterType((*HelloResponse)(nil), "messages.helloResponse")
-	proto.RegisterType((*Ping)(nil), "messages.ping")
-	proto.RegisterType((*Pong)(nil), "messages.pong")
+func (m *LabelRequirement) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type Job struct {
+	Command              string              `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Args                 []string            `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	Env                  map[string]string   `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	WorkingDir           string              `protobuf:"bytes,4,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
+	Timeout              int64               `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	KillGrace            int64               `protobuf:"varint,6,opt,name=killGrace,proto3" json:"killGrace,omitempty"`
+	Labels               map[string]string   `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Resources            *Resources          `protobuf:"bytes,8,opt,name=resources,proto3" json:"resources,omitempty"`
+	Selector             []*LabelRequirement `protobuf:"bytes,9,rep,name=selector,proto3" json:"selector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *Job) Reset()         { *m = Job{} }
+func (m *Job) String() string { return proto.CompactTextString(m) }
+func (*Job) ProtoMessage()    {}
+func (*Job) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{7}
+}
+
+func (m *Job) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Job.Unmarshal(m, b)
+}
+func (m *Job) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Job.Marshal(b, m, deterministic)
+}
+func (m *Job) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Job.Merge(m, src)
+}
+func (m *Job) XXX_Size() int {
+	return xxx_messageInfo_Job.Size(m)
+}
+func (m *Job) XXX_DiscardUnknown() {
+	xxx_messageInfo_Job.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Job proto.InternalMessageInfo
+
+func (m *Job) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *Job) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *Job) GetEnv() map[string]string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *Job) GetWorkingDir() string {
+	if m != nil {
+		return m.WorkingDir
+	}
+	return ""
+}
+
+func (m *Job) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *Job) GetKillGrace() int64 {
+	if m != nil {
+		return m.KillGrace
+	}
+	return 0
+}
+
+func (m *Job) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Job) GetResources() *Resources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *Job) GetSelector() []*LabelRequirement {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
+
+// Work service (workRequest/workResponse, cancelJobRequest/workResponse)
+type WorkRequest struct {
+	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	Job                  []byte   `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	Spec                 *Job     `protobuf:"bytes,3,opt,name=spec,proto3" json:"spec,omitempty"`
+	Attempt              int32    `protobuf:"varint,4,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkRequest) Reset()         { *m = WorkRequest{} }
+func (m *WorkRequest) String() string { return proto.CompactTextString(m) }
+func (*WorkRequest) ProtoMessage()    {}
+func (*WorkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{8}
+}
+
+func (m *WorkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkRequest.Unmarshal(m, b)
+}
+func (m *WorkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkRequest.Marshal(b, m, deterministic)
+}
+func (m *WorkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkRequest.Merge(m, src)
+}
+func (m *WorkRequest) XXX_Size() int {
+	return xxx_messageInfo_WorkRequest.Size(m)
+}
+func (m *WorkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkRequest proto.InternalMessageInfo
+
+func (m *WorkRequest) GetJobID() int32 {
+	if m != nil {
+		return m.JobID
+	}
+	return 0
+}
+
+func (m *WorkRequest) GetJob() []byte {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *WorkRequest) GetSpec() *Job {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+func (m *WorkRequest) GetAttempt() int32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+// Fields 2 to 8 are only set in reply to a job sent gob encoded, whose
+// commander waits for it to finish. Otherwise Work returns straight away and
+// the results are sent with jobStatusReport.
+type WorkResponse struct {
+	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	Output               string   `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	ExitCode             int32    `protobuf:"varint,3,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	Stderr               string   `protobuf:"bytes,4,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	StartTime            int64    `protobuf:"varint,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime              int64    `protobuf:"varint,6,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Signal               string   `protobuf:"bytes,7,opt,name=signal,proto3" json:"signal,omitempty"`
+	Error                string   `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkResponse) Reset()         { *m = WorkResponse{} }
+func (m *WorkResponse) String() string { return proto.CompactTextString(m) }
+func (*WorkResponse) ProtoMessage()    {}
+func (*WorkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{9}
+}
+
+func (m *WorkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkResponse.Unmarshal(m, b)
+}
+func (m *WorkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkResponse.Marshal(b, m, deterministic)
+}
+func (m *WorkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkResponse.Merge(m, src)
+}
+func (m *WorkResponse) XXX_Size() int {
+	return xxx_messageInfo_WorkResponse.Size(m)
+}
+func (m *WorkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkResponse proto.InternalMessageInfo
+
+func (m *WorkResponse) GetJobID() int32 {
+	if m != nil {
+		return m.JobID
+	}
+	return 0
+}
+
+func (m *WorkResponse) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+func (m *WorkResponse) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *WorkResponse) GetStderr() string {
+	if m != nil {
+		return m.Stderr
+	}
+	return ""
+}
+
+func (m *WorkResponse) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *WorkResponse) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *WorkResponse) GetSignal() string {
+	if m != nil {
+		return m.Signal
+	}
+	return ""
+}
+
+func (m *WorkResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// Job service (submitJobRequest/submitJobResponse, getJobRequest/jobInfo, listJobsRequest/listJobsResponse, cancelJobRequest/jobInfo,
+// submitWorkflowRequest/workflowInfo, getWorkflowRequest/workflowInfo, listWorkflowsRequest/listWorkflowsResponse,
+// cancelWorkflowRequest/workflowInfo)
+type RetryPolicy struct {
+	MaxAttempts          int32    `protobuf:"varint,1,opt,name=maxAttempts,proto3" json:"maxAttempts,omitempty"`
+	BackoffBase          int64    `protobuf:"varint,2,opt,name=backoffBase,proto3" json:"backoffBase,omitempty"`
+	BackoffCap           int64    `protobuf:"varint,3,opt,name=backoffCap,proto3" json:"backoffCap,omitempty"`
+	Jitter               float64  `protobuf:"fixed64,4,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	RetryableExitCodes   []int32  `protobuf:"varint,5,rep,packed,name=retryableExitCodes,proto3" json:"retryableExitCodes,omitempty"`
+	DifferentWorker      bool     `protobuf:"varint,6,opt,name=differentWorker,proto3" json:"differentWorker,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetryPolicy) Reset()         { *m = RetryPolicy{} }
+func (m *RetryPolicy) String() string { return proto.CompactTextString(m) }
+func (*RetryPolicy) ProtoMessage()    {}
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{10}
+}
+
+func (m *RetryPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetryPolicy.Unmarshal(m, b)
+}
+func (m *RetryPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetryPolicy.Marshal(b, m, deterministic)
+}
+func (m *RetryPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetryPolicy.Merge(m, src)
+}
+func (m *RetryPolicy) XXX_Size() int {
+	return xxx_messageInfo_RetryPolicy.Size(m)
+}
+func (m *RetryPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetryPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetryPolicy proto.InternalMessageInfo
+
+func (m *RetryPolicy) GetMaxAttempts() int32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetBackoffBase() int64 {
+	if m != nil {
+		return m.BackoffBase
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetBackoffCap() int64 {
+	if m != nil {
+		return m.BackoffCap
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetJitter() float64 {
+	if m != nil {
+		return m.Jitter
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetRetryableExitCodes() []int32 {
+	if m != nil {
+		return m.RetryableExitCodes
+	}
+	return nil
+}
+
+func (m *RetryPolicy) GetDifferentWorker() bool {
+	if m != nil {
+		return m.DifferentWorker
+	}
+	return false
+}
+
+type SubmitJobRequest struct {
+	Retry                *RetryPolicy `protobuf:"bytes,5,opt,name=retry,proto3" json:"retry,omitempty"`
+	Spec                 *Job         `protobuf:"bytes,6,opt,name=spec,proto3" json:"spec,omitempty"`
+	Dispatch             DispatchMode `protobuf:"varint,7,opt,name=dispatch,proto3,enum=messages.DispatchMode" json:"dispatch,omitempty"`
+	Count                int32        `protobuf:"varint,8,opt,name=count,proto3" json:"count,omitempty"`
+	Priority             int32        `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	PriorityClass        string       `protobuf:"bytes,10,opt,name=priorityClass,proto3" json:"priorityClass,omitempty"`
+	Tenant               string       `protobuf:"bytes,11,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	RunAt                int64        `protobuf:"varint,12,opt,name=runAt,proto3" json:"runAt,omitempty"`
+	Delay                int64        `protobuf:"varint,13,opt,name=delay,proto3" json:"delay,omitempty"`
+	Ttl                  int64        `protobuf:"varint,14,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SubmitJobRequest) Reset()         { *m = SubmitJobRequest{} }
+func (m *SubmitJobRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitJobRequest) ProtoMessage()    {}
+func (*SubmitJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{11}
+}
+
+func (m *SubmitJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitJobRequest.Unmarshal(m, b)
+}
+func (m *SubmitJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitJobRequest.Marshal(b, m, deterministic)
+}
+func (m *SubmitJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitJobRequest.Merge(m, src)
+}
+func (m *SubmitJobRequest) XXX_Size() int {
+	return xxx_messageInfo_SubmitJobRequest.Size(m)
+}
+func (m *SubmitJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitJobRequest proto.InternalMessageInfo
+
+func (m *SubmitJobRequest) GetRetry() *RetryPolicy {
+	if m != nil {
+		return m.Retry
+	}
+	return nil
+}
+
+func (m *SubmitJobRequest) GetSpec() *Job {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+func (m *SubmitJobRequest) GetDispatch() DispatchMode {
+	if m != nil {
+		return m.Dispatch
+	}
+	return DispatchMode_DISPATCH_ONE
+}
+
+func (m *SubmitJobRequest) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *SubmitJobRequest) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *SubmitJobRequest) GetPriorityClass() string {
+	if m != nil {
+		return m.PriorityClass
+	}
+	return ""
+}
+
+func (m *SubmitJobRequest) GetTenant() string {
+	if m != nil {
+		return m.Tenant
+	}
+	return ""
+}
+
+func (m *SubmitJobRequest) GetRunAt() int64 {
+	if m != nil {
+		return m.RunAt
+	}
+	return 0
+}
+
+func (m *SubmitJobRequest) GetDelay() int64 {
+	if m != nil {
+		return m.Delay
+	}
+	return 0
+}
+
+func (m *SubmitJobRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+type SubmitJobResponse struct {
+	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubmitJobResponse) Reset()         { *m = SubmitJobResponse{} }
+func (m *SubmitJobResponse) String() string { return proto.CompactTextString(m) }
+func (*SubmitJobResponse) ProtoMessage()    {}
+func (*SubmitJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{12}
+}
+
+func (m *SubmitJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitJobResponse.Unmarshal(m, b)
+}
+func (m *SubmitJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitJobResponse.Marshal(b, m, deterministic)
+}
+func (m *SubmitJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitJobResponse.Merge(m, src)
+}
+func (m *SubmitJobResponse) XXX_Size() int {
+	return xxx_messageInfo_SubmitJobResponse.Size(m)
+}
+func (m *SubmitJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitJobResponse proto.InternalMessageInfo
+
+func (m *SubmitJobResponse) GetJobID() int32 {
+	if m != nil {
+		return m.JobID
+	}
+	return 0
+}
+
+type GetJobRequest struct {
+	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetJobRequest) Reset()         { *m = GetJobRequest{} }
+func (m *GetJobRequest) String() string { return proto.CompactTextString(m) }
+func (*GetJobRequest) ProtoMessage()    {}
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{13}
+}
+
+func (m *GetJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobRequest.Unmarshal(m, b)
+}
+func (m *GetJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobRequest.Marshal(b, m, deterministic)
+}
+func (m *GetJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobRequest.Merge(m, src)
+}
+func (m *GetJobRequest) XXX_Size() int {
+	return xxx_messageInfo_GetJobRequest.Size(m)
+}
+func (m *GetJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobRequest proto.InternalMessageInfo
+
+func (m *GetJobRequest) GetJobID() int32 {
+	if m != nil {
+		return m.JobID
+	}
+	return 0
+}
+
+type JobTransition struct {
+	Status               int32    `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Time                 int64    `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobTransition) Reset()         { *m = JobTransition{} }
+func (m *JobTransition) String() string { return proto.CompactTextString(m) }
+func (*JobTransition) ProtoMessage()    {}
+func (*JobTransition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{14}
+}
+
+func (m *JobTransition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobTransition.Unmarshal(m, b)
+}
+func (m *JobTransition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobTransition.Marshal(b, m, deterministic)
+}
+func (m *JobTransition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobTransition.Merge(m, src)
+}
+func (m *JobTransition) XXX_Size() int {
+	return xxx_messageInfo_JobTransition.Size(m)
+}
+func (m *JobTransition) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobTransition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobTransition proto.InternalMessageInfo
+
+func (m *JobTransition) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *JobTransition) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *JobTransition) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type JobAttempt struct {
+	Number               int32    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Worker               string   `protobuf:"bytes,2,opt,name=worker,proto3" json:"worker,omitempty"`
+	Status               int32    `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	ExitCode             int32    `protobuf:"varint,4,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	Signal               string   `protobuf:"bytes,5,opt,name=signal,proto3" json:"signal,omitempty"`
+	Error                string   `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	StartTime            int64    `protobuf:"varint,7,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime              int64    `protobuf:"varint,8,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	PreemptedBy          int32    `protobuf:"varint,9,opt,name=preemptedBy,proto3" json:"preemptedBy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobAttempt) Reset()         { *m = JobAttempt{} }
+func (m *JobAttempt) String() string { return proto.CompactTextString(m) }
+func (*JobAttempt) ProtoMessage()    {}
+func (*JobAttempt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{15}
+}
+
+func (m *JobAttempt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobAttempt.Unmarshal(m, b)
+}
+func (m *JobAttempt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobAttempt.Marshal(b, m, deterministic)
+}
+func (m *JobAttempt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobAttempt.Merge(m, src)
+}
+func (m *JobAttempt) XXX_Size() int {
+	return xxx_messageInfo_JobAttempt.Size(m)
+}
+func (m *JobAttempt) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobAttempt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobAttempt proto.InternalMessageInfo
+
+func (m *JobAttempt) GetNumber() int32 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *JobAttempt) GetWorker() string {
+	if m != nil {
+		return m.Worker
+	}
+	return ""
+}
+
+func (m *JobAttempt) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *JobAttempt) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *JobAttempt) GetSignal() string {
+	if m != nil {
+		return m.Signal
+	}
+	return ""
+}
+
+func (m *JobAttempt) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *JobAttempt) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *JobAttempt) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *JobAttempt) GetPreemptedBy() int32 {
+	if m != nil {
+		return m.PreemptedBy
+	}
+	return 0
+}
+
+type JobInfo struct {
+	JobID                int32            `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	Command              string           `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	Args                 []string         `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	Status               int32            `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"`
+	Worker               string           `protobuf:"bytes,5,opt,name=worker,proto3" json:"worker,omitempty"`
+	History              []*JobTransition `protobuf:"bytes,6,rep,name=history,proto3" json:"history,omitempty"`
+	ExitCode             int32            `protobuf:"varint,7,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	Signal               string           `protobuf:"bytes,8,opt,name=signal,proto3" json:"signal,omitempty"`
+	Error                string           `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	Timeout              int64            `protobuf:"varint,10,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	KillGrace            int64            `protobuf:"varint,11,opt,name=killGrace,proto3" json:"killGrace,omitempty"`
+	Retry                *RetryPolicy     `protobuf:"bytes,12,opt,name=retry,proto3" json:"retry,omitempty"`
+	Attempts             []*JobAttempt    `protobuf:"bytes,13,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	NotBefore            int64            `protobuf:"varint,14,opt,name=notBefore,proto3" json:"notBefore,omitempty"`
+	Spec                 *Job             `protobuf:"bytes,15,opt,name=spec,proto3" json:"spec,omitempty"`
+	LeaseEnd             int64            `protobuf:"varint,16,opt,name=leaseEnd,proto3" json:"leaseEnd,omitempty"`
+	Dispatch             DispatchMode     `protobuf:"varint,17,opt,name=dispatch,proto3,enum=messages.DispatchMode" json:"dispatch,omitempty"`
+	Count                int32            `protobuf:"varint,18,opt,name=count,proto3" json:"count,omitempty"`
+	Parent               int32            `protobuf:"varint,19,opt,name=parent,proto3" json:"parent,omitempty"`
+	FanOut               *FanOutStatus    `protobuf:"bytes,20,opt,name=fanOut,proto3" json:"fanOut,omitempty"`
+	Priority             int32            `protobuf:"varint,21,opt,name=priority,proto3" json:"priority,omitempty"`
+	PriorityClass        string           `protobuf:"bytes,22,opt,name=priorityClass,proto3" json:"priorityClass,omitempty"`
+	PreemptedBy          int32            `protobuf:"varint,23,opt,name=preemptedBy,proto3" json:"preemptedBy,omitempty"`
+	Tenant               string           `protobuf:"bytes,24,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Workflow             int32            `protobuf:"varint,25,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	Name                 string           `protobuf:"bytes,26,opt,name=name,proto3" json:"name,omitempty"`
+	DependsOn            []int32          `protobuf:"varint,27,rep,packed,name=dependsOn,proto3" json:"dependsOn,omitempty"`
+	Schedule             int32            `protobuf:"varint,28,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	RunAt                int64            `protobuf:"varint,29,opt,name=runAt,proto3" json:"runAt,omitempty"`
+	Ttl                  int64            `protobuf:"varint,30,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	ExpiresAt            int64            `protobuf:"varint,31,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *JobInfo) Reset()         { *m = JobInfo{} }
+func (m *JobInfo) String() string { return proto.CompactTextString(m) }
+func (*JobInfo) ProtoMessage()    {}
+func (*JobInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{16}
+}
+
+func (m *JobInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobInfo.Unmarshal(m, b)
+}
+func (m *JobInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobInfo.Marshal(b, m, deterministic)
+}
+func (m *JobInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobInfo.Merge(m, src)
+}
+func (m *JobInfo) XXX_Size() int {
+	return xxx_messageInfo_JobInfo.Size(m)
+}
+func (m *JobInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobInfo proto.InternalMessageInfo
+
+func (m *JobInfo) GetJobID() int32 {
+	if m != nil {
+		return m.JobID
+	}
+	return 0
+}
+
+func (m *JobInfo) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *JobInfo) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *JobInfo) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *JobInfo) GetWorker() string {
+	if m != nil {
+		return m.Worker
+	}
+	return ""
+}
+
+func (m *JobInfo) GetHistory() []*JobTransition {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+func (m *JobInfo) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *JobInfo) GetSignal() string {
+	if m != nil {
+		return m.Signal
+	}
+	return ""
+}
+
+func (m *JobInfo) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *JobInfo) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *JobInfo) GetKillGrace() int64 {
+	if m != nil {
+		return m.KillGrace
+	}
+	return 0
+}
+
+func (m *JobInfo) GetRetry() *RetryPolicy {
+	if m != nil {
+		return m.Retry
+	}
+	return nil
+}
+
+func (m *JobInfo) GetAttempts() []*JobAttempt {
+	if m != nil {
+		return m.Attempts
+	}
+	return nil
+}
+
+func (m *JobInfo) GetNotBefore() int64 {
+	if m != nil {
+		return m.NotBefore
+	}
+	return 0
+}
+
+func (m *JobInfo) GetSpec() *Job {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+func (m *JobInfo) GetLeaseEnd() int64 {
+	if m != nil {
+		return m.LeaseEnd
+	}
+	return 0
+}
+
+func (m *JobInfo) GetDispatch() DispatchMode {
+	if m != nil {
+		return m.Dispatch
+	}
+	return DispatchMode_DISPATCH_ONE
+}
+
+func (m *JobInfo) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *JobInfo) GetParent() int32 {
+	if m != nil {
+		return m.Parent
+	}
+	return 0
+}
+
+func (m *JobInfo) GetFanOut() *FanOutStatus {
+	if m != nil {
+		return m.FanOut
+	}
+	return nil
+}
+
+func (m *JobInfo) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *JobInfo) GetPriorityClass() string {
+	if m != nil {
+		return m.PriorityClass
+	}
+	return ""
+}
+
+func (m *JobInfo) GetPreemptedBy() int32 {
+	if m != nil {
+		return m.PreemptedBy
+	}
+	return 0
+}
+
+func (m *JobInfo) GetTenant() string {
+	if m != nil {
+		return m.Tenant
+	}
+	return ""
+}
+
+func (m *JobInfo) GetWorkflow() int32 {
+	if m != nil {
+		return m.Workflow
+	}
+	return 0
+}
+
+func (m *JobInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *JobInfo) GetDependsOn() []int32 {
+	if m != nil {
+		return m.DependsOn
+	}
+	return nil
+}
+
+func (m *JobInfo) GetSchedule() int32 {
+	if m != nil {
+		return m.Schedule
+	}
+	return 0
+}
+
+func (m *JobInfo) GetRunAt() int64 {
+	if m != nil {
+		return m.RunAt
+	}
+	return 0
+}
+
+func (m *JobInfo) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *JobInfo) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+// How the runs of a job dispatched to more than one worker are getting on
+type FanOutStatus struct {
+	Total                int32           `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Pending              int32           `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"`
+	Running              int32           `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+	Succeeded            int32           `protobuf:"varint,4,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed               int32           `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	Cancelled            int32           `protobuf:"varint,6,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	Results              []*FanOutResult `protobuf:"bytes,7,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *FanOutStatus) Reset()         { *m = FanOutStatus{} }
+func (m *FanOutStatus) String() string { return proto.CompactTextString(m) }
+func (*FanOutStatus) ProtoMessage()    {}
+func (*FanOutStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{17}
+}
+
+func (m *FanOutStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FanOutStatus.Unmarshal(m, b)
+}
+func (m *FanOutStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FanOutStatus.Marshal(b, m, deterministic)
+}
+func (m *FanOutStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FanOutStatus.Merge(m, src)
+}
+func (m *FanOutStatus) XXX_Size() int {
+	return xxx_messageInfo_FanOutStatus.Size(m)
+}
+func (m *FanOutStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_FanOutStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FanOutStatus proto.InternalMessageInfo
+
+func (m *FanOutStatus) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *FanOutStatus) GetPending() int32 {
+	if m != nil {
+		return m.Pending
+	}
+	return 0
+}
+
+func (m *FanOutStatus) GetRunning() int32 {
+	if m != nil {
+		return m.Running
+	}
+	return 0
+}
+
+func (m *FanOutStatus) GetSucceeded() int32 {
+	if m != nil {
+		return m.Succeeded
+	}
+	return 0
+}
+
+func (m *FanOutStatus) GetFailed() int32 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+func (m *FanOutStatus) GetCancelled() int32 {
+	if m != nil {
+		return m.Cancelled
+	}
+	return 0
+}
+
+func (m *FanOutStatus) GetResults() []*FanOutResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// The run of a fan-out job on one worker, which is a job of its own
+type FanOutResult struct {
+	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	Worker               string   `protobuf:"bytes,2,opt,name=worker,proto3" json:"worker,omitempty"`
+	Status               int32    `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	ExitCode             int32    `protobuf:"varint,4,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FanOutResult) Reset()         { *m = FanOutResult{} }
+func (m *FanOutResult) String() string { return proto.CompactTextString(m) }
+func (*FanOutResult) ProtoMessage()    {}
+func (*FanOutResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{18}
+}
+
+func (m *FanOutResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FanOutResult.Unmarshal(m, b)
+}
+func (m *FanOutResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FanOutResult.Marshal(b, m, deterministic)
+}
+func (m *FanOutResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FanOutResult.Merge(m, src)
+}
+func (m *FanOutResult) XXX_Size() int {
+	return xxx_messageInfo_FanOutResult.Size(m)
+}
+func (m *FanOutResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_FanOutResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FanOutResult proto.InternalMessageInfo
+
+func (m *FanOutResult) GetJobID() int32 {
+	if m != nil {
+		return m.JobID
+	}
+	return 0
+}
+
+func (m *FanOutResult) GetWorker() string {
+	if m != nil {
+		return m.Worker
+	}
+	return ""
+}
+
+func (m *FanOutResult) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *FanOutResult) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *FanOutResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ListJobsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobsRequest) Reset()         { *m = ListJobsRequest{} }
+func (m *ListJobsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListJobsRequest) ProtoMessage()    {}
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{19}
+}
+
+func (m *ListJobsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsRequest.Unmarshal(m, b)
+}
+func (m *ListJobsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListJobsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsRequest.Merge(m, src)
+}
+func (m *ListJobsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListJobsRequest.Size(m)
+}
+func (m *ListJobsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobsRequest proto.InternalMessageInfo
+
+type ListJobsResponse struct {
+	Jobs                 []*JobInfo `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListJobsResponse) Reset()         { *m = ListJobsResponse{} }
+func (m *ListJobsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListJobsResponse) ProtoMessage()    {}
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{20}
+}
+
+func (m *ListJobsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsResponse.Unmarshal(m, b)
+}
+func (m *ListJobsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListJobsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsResponse.Merge(m, src)
+}
+func (m *ListJobsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListJobsResponse.Size(m)
+}
+func (m *ListJobsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobsResponse proto.InternalMessageInfo
+
+func (m *ListJobsResponse) GetJobs() []*JobInfo {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+type CancelJobRequest struct {
+	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelJobRequest) Reset()         { *m = CancelJobRequest{} }
+func (m *CancelJobRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelJobRequest) ProtoMessage()    {}
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{21}
+}
+
+func (m *CancelJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelJobRequest.Unmarshal(m, b)
+}
+func (m *CancelJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelJobRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelJobRequest.Merge(m, src)
+}
+func (m *CancelJobRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelJobRequest.Size(m)
+}
+func (m *CancelJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelJobRequest proto.InternalMessageInfo
+
+func (m *CancelJobRequest) GetJobID() int32 {
+	if m != nil {
+		return m.JobID
+	}
+	return 0
+}
+
+// A job in a workflow, run once the jobs it depends on have succeeded
+type WorkflowJob struct {
+	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Job                  *SubmitJobRequest `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	DependsOn            []string          `protobuf:"bytes,3,rep,name=dependsOn,proto3" json:"dependsOn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *WorkflowJob) Reset()         { *m = WorkflowJob{} }
+func (m *WorkflowJob) String() string { return proto.CompactTextString(m) }
+func (*WorkflowJob) ProtoMessage()    {}
+func (*WorkflowJob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{22}
+}
+
+func (m *WorkflowJob) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkflowJob.Unmarshal(m, b)
+}
+func (m *WorkflowJob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkflowJob.Marshal(b, m, deterministic)
+}
+func (m *WorkflowJob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkflowJob.Merge(m, src)
+}
+func (m *WorkflowJob) XXX_Size() int {
+	return xxx_messageInfo_WorkflowJob.Size(m)
+}
+func (m *WorkflowJob) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkflowJob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkflowJob proto.InternalMessageInfo
+
+func (m *WorkflowJob) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *WorkflowJob) GetJob() *SubmitJobRequest {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *WorkflowJob) GetDependsOn() []string {
+	if m != nil {
+		return m.DependsOn
+	}
+	return nil
+}
+
+type SubmitWorkflowRequest struct {
+	Jobs                 []*WorkflowJob `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	OnFailure            FailurePolicy  `protobuf:"varint,2,opt,name=onFailure,proto3,enum=messages.FailurePolicy" json:"onFailure,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SubmitWorkflowRequest) Reset()         { *m = SubmitWorkflowRequest{} }
+func (m *SubmitWorkflowRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitWorkflowRequest) ProtoMessage()    {}
+func (*SubmitWorkflowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{23}
+}
+
+func (m *SubmitWorkflowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitWorkflowRequest.Unmarshal(m, b)
+}
+func (m *SubmitWorkflowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitWorkflowRequest.Marshal(b, m, deterministic)
+}
+func (m *SubmitWorkflowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitWorkflowRequest.Merge(m, src)
+}
+func (m *SubmitWorkflowRequest) XXX_Size() int {
+	return xxx_messageInfo_SubmitWorkflowRequest.Size(m)
+}
+func (m *SubmitWorkflowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitWorkflowRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitWorkflowRequest proto.InternalMessageInfo
+
+func (m *SubmitWorkflowRequest) GetJobs() []*WorkflowJob {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+func (m *SubmitWorkflowRequest) GetOnFailure() FailurePolicy {
+	if m != nil {
+		return m.OnFailure
+	}
+	return FailurePolicy_SKIP_DEPENDENTS
+}
+
+type GetWorkflowRequest struct {
+	WorkflowID           int32    `protobuf:"varint,1,opt,name=workflowID,proto3" json:"workflowID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWorkflowRequest) Reset()         { *m = GetWorkflowRequest{} }
+func (m *GetWorkflowRequest) String() string { return proto.CompactTextString(m) }
+func (*GetWorkflowRequest) ProtoMessage()    {}
+func (*GetWorkflowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{24}
+}
+
+func (m *GetWorkflowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWorkflowRequest.Unmarshal(m, b)
+}
+func (m *GetWorkflowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWorkflowRequest.Marshal(b, m, deterministic)
+}
+func (m *GetWorkflowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWorkflowRequest.Merge(m, src)
+}
+func (m *GetWorkflowRequest) XXX_Size() int {
+	return xxx_messageInfo_GetWorkflowRequest.Size(m)
+}
+func (m *GetWorkflowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWorkflowRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWorkflowRequest proto.InternalMessageInfo
+
+func (m *GetWorkflowRequest) GetWorkflowID() int32 {
+	if m != nil {
+		return m.WorkflowID
+	}
+	return 0
+}
+
+type WorkflowInfo struct {
+	WorkflowID           int32         `protobuf:"varint,1,opt,name=workflowID,proto3" json:"workflowID,omitempty"`
+	Status               int32         `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	OnFailure            FailurePolicy `protobuf:"varint,3,opt,name=onFailure,proto3,enum=messages.FailurePolicy" json:"onFailure,omitempty"`
+	Jobs                 []*JobInfo    `protobuf:"bytes,4,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *WorkflowInfo) Reset()         { *m = WorkflowInfo{} }
+func (m *WorkflowInfo) String() string { return proto.CompactTextString(m) }
+func (*WorkflowInfo) ProtoMessage()    {}
+func (*WorkflowInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{25}
+}
+
+func (m *WorkflowInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkflowInfo.Unmarshal(m, b)
+}
+func (m *WorkflowInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkflowInfo.Marshal(b, m, deterministic)
+}
+func (m *WorkflowInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkflowInfo.Merge(m, src)
+}
+func (m *WorkflowInfo) XXX_Size() int {
+	return xxx_messageInfo_WorkflowInfo.Size(m)
+}
+func (m *WorkflowInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkflowInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkflowInfo proto.InternalMessageInfo
+
+func (m *WorkflowInfo) GetWorkflowID() int32 {
+	if m != nil {
+		return m.WorkflowID
+	}
+	return 0
+}
+
+func (m *WorkflowInfo) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *WorkflowInfo) GetOnFailure() FailurePolicy {
+	if m != nil {
+		return m.OnFailure
+	}
+	return FailurePolicy_SKIP_DEPENDENTS
+}
+
+func (m *WorkflowInfo) GetJobs() []*JobInfo {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+type ListWorkflowsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWorkflowsRequest) Reset()         { *m = ListWorkflowsRequest{} }
+func (m *ListWorkflowsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWorkflowsRequest) ProtoMessage()    {}
+func (*ListWorkflowsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{26}
+}
+
+func (m *ListWorkflowsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWorkflowsRequest.Unmarshal(m, b)
+}
+func (m *ListWorkflowsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWorkflowsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWorkflowsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWorkflowsRequest.Merge(m, src)
+}
+func (m *ListWorkflowsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWorkflowsRequest.Size(m)
+}
+func (m *ListWorkflowsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWorkflowsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWorkflowsRequest proto.InternalMessageInfo
+
+type ListWorkflowsResponse struct {
+	Workflows            []*WorkflowInfo `protobuf:"bytes,1,rep,name=workflows,proto3" json:"workflows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListWorkflowsResponse) Reset()         { *m = ListWorkflowsResponse{} }
+func (m *ListWorkflowsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListWorkflowsResponse) ProtoMessage()    {}
+func (*ListWorkflowsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{27}
+}
+
+func (m *ListWorkflowsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWorkflowsResponse.Unmarshal(m, b)
+}
+func (m *ListWorkflowsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWorkflowsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListWorkflowsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWorkflowsResponse.Merge(m, src)
+}
+func (m *ListWorkflowsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListWorkflowsResponse.Size(m)
+}
+func (m *ListWorkflowsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWorkflowsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWorkflowsResponse proto.InternalMessageInfo
+
+func (m *ListWorkflowsResponse) GetWorkflows() []*WorkflowInfo {
+	if m != nil {
+		return m.Workflows
+	}
+	return nil
+}
+
+type CancelWorkflowRequest struct {
+	WorkflowID           int32    `protobuf:"varint,1,opt,name=workflowID,proto3" json:"workflowID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelWorkflowRequest) Reset()         { *m = CancelWorkflowRequest{} }
+func (m *CancelWorkflowRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelWorkflowRequest) ProtoMessage()    {}
+func (*CancelWorkflowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{28}
+}
+
+func (m *CancelWorkflowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelWorkflowRequest.Unmarshal(m, b)
+}
+func (m *CancelWorkflowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelWorkflowRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelWorkflowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelWorkflowRequest.Merge(m, src)
+}
+func (m *CancelWorkflowRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelWorkflowRequest.Size(m)
+}
+func (m *CancelWorkflowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelWorkflowRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelWorkflowRequest proto.InternalMessageInfo
+
+func (m *CancelWorkflowRequest) GetWorkflowID() int32 {
+	if m != nil {
+		return m.WorkflowID
+	}
+	return 0
+}
+
+// Cluster service (listWorkersRequest/listWorkersResponse,
+// removeWorkerRequest/removeWorkerResponse)
+type WorkerInfo struct {
+	Id                   string            `protobuf:"bytes,8,opt,name=id,proto3" json:"id,omitempty"`
+	Ip                   string            `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Fqdn                 string            `protobuf:"bytes,2,opt,name=fqdn,proto3" json:"fqdn,omitempty"`
+	Status               string            `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	NetworkErrors        int32             `protobuf:"varint,4,opt,name=networkErrors,proto3" json:"networkErrors,omitempty"`
+	ProtocolVersion      int32             `protobuf:"varint,5,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	Features             []string          `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`
+	Mode                 string            `protobuf:"bytes,7,opt,name=mode,proto3" json:"mode,omitempty"`
+	Addresses            []string          `protobuf:"bytes,9,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Resources            *WorkerResources  `protobuf:"bytes,11,opt,name=resources,proto3" json:"resources,omitempty"`
+	Reserved             *Resources        `protobuf:"bytes,12,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Jobs                 int32             `protobuf:"varint,13,opt,name=jobs,proto3" json:"jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *WorkerInfo) Reset()         { *m = WorkerInfo{} }
+func (m *WorkerInfo) String() string { return proto.CompactTextString(m) }
+func (*WorkerInfo) ProtoMessage()    {}
+func (*WorkerInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{29}
+}
+
+func (m *WorkerInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkerInfo.Unmarshal(m, b)
+}
+func (m *WorkerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkerInfo.Marshal(b, m, deterministic)
+}
+func (m *WorkerInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkerInfo.Merge(m, src)
+}
+func (m *WorkerInfo) XXX_Size() int {
+	return xxx_messageInfo_WorkerInfo.Size(m)
+}
+func (m *WorkerInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkerInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkerInfo proto.InternalMessageInfo
+
+func (m *WorkerInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *WorkerInfo) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *WorkerInfo) GetFqdn() string {
+	if m != nil {
+		return m.Fqdn
+	}
+	return ""
+}
+
+func (m *WorkerInfo) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *WorkerInfo) GetNetworkErrors() int32 {
+	if m != nil {
+		return m.NetworkErrors
+	}
+	return 0
+}
+
+func (m *WorkerInfo) GetProtocolVersion() int32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *WorkerInfo) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *WorkerInfo) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *WorkerInfo) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *WorkerInfo) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *WorkerInfo) GetResources() *WorkerResources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *WorkerInfo) GetReserved() *Resources {
+	if m != nil {
+		return m.Reserved
+	}
+	return nil
+}
+
+func (m *WorkerInfo) GetJobs() int32 {
+	if m != nil {
+		return m.Jobs
+	}
+	return 0
+}
+
+type ListWorkersRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWorkersRequest) Reset()         { *m = ListWorkersRequest{} }
+func (m *ListWorkersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWorkersRequest) ProtoMessage()    {}
+func (*ListWorkersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{30}
+}
+
+func (m *ListWorkersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWorkersRequest.Unmarshal(m, b)
+}
+func (m *ListWorkersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWorkersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWorkersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWorkersRequest.Merge(m, src)
+}
+func (m *ListWorkersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWorkersRequest.Size(m)
+}
+func (m *ListWorkersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWorkersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWorkersRequest proto.InternalMessageInfo
+
+type ListWorkersResponse struct {
+	Workers              []*WorkerInfo `protobuf:"bytes,1,rep,name=workers,proto3" json:"workers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListWorkersResponse) Reset()         { *m = ListWorkersResponse{} }
+func (m *ListWorkersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListWorkersResponse) ProtoMessage()    {}
+func (*ListWorkersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{31}
+}
+
+func (m *ListWorkersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWorkersResponse.Unmarshal(m, b)
+}
+func (m *ListWorkersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWorkersResponse.Marshal(b, m, deterministic)
+}
+func (m *ListWorkersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWorkersResponse.Merge(m, src)
+}
+func (m *ListWorkersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListWorkersResponse.Size(m)
+}
+func (m *ListWorkersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWorkersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWorkersResponse proto.InternalMessageInfo
+
+func (m *ListWorkersResponse) GetWorkers() []*WorkerInfo {
+	if m != nil {
+		return m.Workers
+	}
+	return nil
+}
+
+type RemoveWorkerRequest struct {
+	Ip                   string   `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveWorkerRequest) Reset()         { *m = RemoveWorkerRequest{} }
+func (m *RemoveWorkerRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveWorkerRequest) ProtoMessage()    {}
+func (*RemoveWorkerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{32}
+}
+
+func (m *RemoveWorkerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveWorkerRequest.Unmarshal(m, b)
+}
+func (m *RemoveWorkerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveWorkerRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveWorkerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveWorkerRequest.Merge(m, src)
+}
+func (m *RemoveWorkerRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveWorkerRequest.Size(m)
+}
+func (m *RemoveWorkerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveWorkerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveWorkerRequest proto.InternalMessageInfo
+
+func (m *RemoveWorkerRequest) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *RemoveWorkerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type RemoveWorkerResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveWorkerResponse) Reset()         { *m = RemoveWorkerResponse{} }
+func (m *RemoveWorkerResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveWorkerResponse) ProtoMessage()    {}
+func (*RemoveWorkerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{33}
+}
+
+func (m *RemoveWorkerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveWorkerResponse.Unmarshal(m, b)
+}
+func (m *RemoveWorkerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveWorkerResponse.Marshal(b, m, deterministic)
+}
+func (m *RemoveWorkerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveWorkerResponse.Merge(m, src)
+}
+func (m *RemoveWorkerResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveWorkerResponse.Size(m)
+}
+func (m *RemoveWorkerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveWorkerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveWorkerResponse proto.InternalMessageInfo
+
+// How a tenant is getting on with the share of worker slots it is due
+type TenantInfo struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Weight               float64  `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Running              int32    `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+	Waiting              int32    `protobuf:"varint,4,opt,name=waiting,proto3" json:"waiting,omitempty"`
+	Usage                float64  `protobuf:"fixed64,5,opt,name=usage,proto3" json:"usage,omitempty"`
+	Share                float64  `protobuf:"fixed64,6,opt,name=share,proto3" json:"share,omitempty"`
+	FairShare            float64  `protobuf:"fixed64,7,opt,name=fairShare,proto3" json:"fairShare,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TenantInfo) Reset()         { *m = TenantInfo{} }
+func (m *TenantInfo) String() string { return proto.CompactTextString(m) }
+func (*TenantInfo) ProtoMessage()    {}
+func (*TenantInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{34}
+}
+
+func (m *TenantInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TenantInfo.Unmarshal(m, b)
+}
+func (m *TenantInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TenantInfo.Marshal(b, m, deterministic)
+}
+func (m *TenantInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TenantInfo.Merge(m, src)
+}
+func (m *TenantInfo) XXX_Size() int {
+	return xxx_messageInfo_TenantInfo.Size(m)
+}
+func (m *TenantInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_TenantInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TenantInfo proto.InternalMessageInfo
+
+func (m *TenantInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TenantInfo) GetWeight() float64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *TenantInfo) GetRunning() int32 {
+	if m != nil {
+		return m.Running
+	}
+	return 0
+}
+
+func (m *TenantInfo) GetWaiting() int32 {
+	if m != nil {
+		return m.Waiting
+	}
+	return 0
+}
+
+func (m *TenantInfo) GetUsage() float64 {
+	if m != nil {
+		return m.Usage
+	}
+	return 0
+}
+
+func (m *TenantInfo) GetShare() float64 {
+	if m != nil {
+		return m.Share
+	}
+	return 0
+}
+
+func (m *TenantInfo) GetFairShare() float64 {
+	if m != nil {
+		return m.FairShare
+	}
+	return 0
+}
+
+type ListTenantsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTenantsRequest) Reset()         { *m = ListTenantsRequest{} }
+func (m *ListTenantsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTenantsRequest) ProtoMessage()    {}
+func (*ListTenantsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{35}
+}
+
+func (m *ListTenantsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTenantsRequest.Unmarshal(m, b)
+}
+func (m *ListTenantsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTenantsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTenantsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTenantsRequest.Merge(m, src)
+}
+func (m *ListTenantsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTenantsRequest.Size(m)
+}
+func (m *ListTenantsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTenantsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTenantsRequest proto.InternalMessageInfo
+
+type ListTenantsResponse struct {
+	Tenants              []*TenantInfo `protobuf:"bytes,1,rep,name=tenants,proto3" json:"tenants,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListTenantsResponse) Reset()         { *m = ListTenantsResponse{} }
+func (m *ListTenantsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTenantsResponse) ProtoMessage()    {}
+func (*ListTenantsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{36}
+}
+
+func (m *ListTenantsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTenantsResponse.Unmarshal(m, b)
+}
+func (m *ListTenantsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTenantsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTenantsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTenantsResponse.Merge(m, src)
+}
+func (m *ListTenantsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTenantsResponse.Size(m)
+}
+func (m *ListTenantsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTenantsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTenantsResponse proto.InternalMessageInfo
+
+func (m *ListTenantsResponse) GetTenants() []*TenantInfo {
+	if m != nil {
+		return m.Tenants
+	}
+	return nil
+}
+
+// Job status service (jobStatusReport/jobStatusAck), used by workers to tell
+// the commander how the jobs it sent them are getting on
+type JobStatusReport struct {
+	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	Worker               string   `protobuf:"bytes,2,opt,name=worker,proto3" json:"worker,omitempty"`
+	Status               int32    `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	Time                 int64    `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	ExitCode             int32    `protobuf:"varint,5,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	Output               string   `protobuf:"bytes,6,opt,name=output,proto3" json:"output,omitempty"`
+	Stderr               string   `protobuf:"bytes,7,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	StartTime            int64    `protobuf:"varint,8,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	Signal               string   `protobuf:"bytes,9,opt,name=signal,proto3" json:"signal,omitempty"`
+	Error                string   `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	Attempt              int32    `protobuf:"varint,11,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobStatusReport) Reset()         { *m = JobStatusReport{} }
+func (m *JobStatusReport) String() string { return proto.CompactTextString(m) }
+func (*JobStatusReport) ProtoMessage()    {}
+func (*JobStatusReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{37}
+}
+
+func (m *JobStatusReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobStatusReport.Unmarshal(m, b)
+}
+func (m *JobStatusReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobStatusReport.Marshal(b, m, deterministic)
+}
+func (m *JobStatusReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobStatusReport.Merge(m, src)
+}
+func (m *JobStatusReport) XXX_Size() int {
+	return xxx_messageInfo_JobStatusReport.Size(m)
+}
+func (m *JobStatusReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobStatusReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobStatusReport proto.InternalMessageInfo
+
+func (m *JobStatusReport) GetJobID() int32 {
+	if m != nil {
+		return m.JobID
+	}
+	return 0
+}
+
+func (m *JobStatusReport) GetWorker() string {
+	if m != nil {
+		return m.Worker
+	}
+	return ""
+}
+
+func (m *JobStatusReport) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *JobStatusReport) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *JobStatusReport) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *JobStatusReport) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+func (m *JobStatusReport) GetStderr() string {
+	if m != nil {
+		return m.Stderr
+	}
+	return ""
+}
+
+func (m *JobStatusReport) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *JobStatusReport) GetSignal() string {
+	if m != nil {
+		return m.Signal
+	}
+	return ""
+}
+
+func (m *JobStatusReport) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *JobStatusReport) GetAttempt() int32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+type JobStatusAck struct {
+	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobStatusAck) Reset()         { *m = JobStatusAck{} }
+func (m *JobStatusAck) String() string { return proto.CompactTextString(m) }
+func (*JobStatusAck) ProtoMessage()    {}
+func (*JobStatusAck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{38}
+}
+
+func (m *JobStatusAck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobStatusAck.Unmarshal(m, b)
+}
+func (m *JobStatusAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobStatusAck.Marshal(b, m, deterministic)
+}
+func (m *JobStatusAck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobStatusAck.Merge(m, src)
+}
+func (m *JobStatusAck) XXX_Size() int {
+	return xxx_messageInfo_JobStatusAck.Size(m)
+}
+func (m *JobStatusAck) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobStatusAck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobStatusAck proto.InternalMessageInfo
+
+func (m *JobStatusAck) GetJobID() int32 {
+	if m != nil {
+		return m.JobID
+	}
+	return 0
+}
+
+type RequestStdOut struct {
+	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	StdoutOffset         int64    `protobuf:"varint,2,opt,name=stdoutOffset,proto3" json:"stdoutOffset,omitempty"`
+	StderrOffset         int64    `protobuf:"varint,3,opt,name=stderrOffset,proto3" json:"stderrOffset,omitempty"`
+	Follow               bool     `protobuf:"varint,4,opt,name=follow,proto3" json:"follow,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestStdOut) Reset()         { *m = RequestStdOut{} }
+func (m *RequestStdOut) String() string { return proto.CompactTextString(m) }
+func (*RequestStdOut) ProtoMessage()    {}
+func (*RequestStdOut) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{39}
+}
+
+func (m *RequestStdOut) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestStdOut.Unmarshal(m, b)
+}
+func (m *RequestStdOut) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestStdOut.Marshal(b, m, deterministic)
+}
+func (m *RequestStdOut) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestStdOut.Merge(m, src)
+}
+func (m *RequestStdOut) XXX_Size() int {
+	return xxx_messageInfo_RequestStdOut.Size(m)
+}
+func (m *RequestStdOut) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestStdOut.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestStdOut proto.InternalMessageInfo
+
+func (m *RequestStdOut) GetJobID() int32 {
+	if m != nil {
+		return m.JobID
+	}
+	return 0
+}
+
+func (m *RequestStdOut) GetStdoutOffset() int64 {
+	if m != nil {
+		return m.StdoutOffset
+	}
+	return 0
+}
+
+func (m *RequestStdOut) GetStderrOffset() int64 {
+	if m != nil {
+		return m.StderrOffset
+	}
+	return 0
+}
+
+func (m *RequestStdOut) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+type ResponseStdOut struct {
+	JobID                int32        `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	Data                 string       `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Stream               OutputStream `protobuf:"varint,3,opt,name=stream,proto3,enum=messages.OutputStream" json:"stream,omitempty"`
+	Offset               int64        `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ResponseStdOut) Reset()         { *m = ResponseStdOut{} }
+func (m *ResponseStdOut) String() string { return proto.CompactTextString(m) }
+func (*ResponseStdOut) ProtoMessage()    {}
+func (*ResponseStdOut) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{40}
+}
+
+func (m *ResponseStdOut) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResponseStdOut.Unmarshal(m, b)
+}
+func (m *ResponseStdOut) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResponseStdOut.Marshal(b, m, deterministic)
+}
+func (m *ResponseStdOut) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseStdOut.Merge(m, src)
+}
+func (m *ResponseStdOut) XXX_Size() int {
+	return xxx_messageInfo_ResponseStdOut.Size(m)
+}
+func (m *ResponseStdOut) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseStdOut.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseStdOut proto.InternalMessageInfo
+
+func (m *ResponseStdOut) GetJobID() int32 {
+	if m != nil {
+		return m.JobID
+	}
+	return 0
+}
+
+func (m *ResponseStdOut) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *ResponseStdOut) GetStream() OutputStream {
+	if m != nil {
+		return m.Stream
+	}
+	return OutputStream_STDOUT
+}
+
+func (m *ResponseStdOut) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+// Lease service (leaseWorkRequest/leaseWorkResponse,
+// renewLeaseRequest/renewLeaseResponse), for workers in pull mode which ask
+// for work instead of having it sent to them
+type LeaseWorkRequest struct {
+	Worker               string           `protobuf:"bytes,1,opt,name=worker,proto3" json:"worker,omitempty"`
+	Capacity             int32            `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Resources            *WorkerResources `protobuf:"bytes,3,opt,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *LeaseWorkRequest) Reset()         { *m = LeaseWorkRequest{} }
+func (m *LeaseWorkRequest) String() string { return proto.CompactTextString(m) }
+func (*LeaseWorkRequest) ProtoMessage()    {}
+func (*LeaseWorkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{41}
+}
+
+func (m *LeaseWorkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaseWorkRequest.Unmarshal(m, b)
+}
+func (m *LeaseWorkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaseWorkRequest.Marshal(b, m, deterministic)
+}
+func (m *LeaseWorkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseWorkRequest.Merge(m, src)
+}
+func (m *LeaseWorkRequest) XXX_Size() int {
+	return xxx_messageInfo_LeaseWorkRequest.Size(m)
+}
+func (m *LeaseWorkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseWorkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseWorkRequest proto.InternalMessageInfo
+
+func (m *LeaseWorkRequest) GetWorker() string {
+	if m != nil {
+		return m.Worker
+	}
+	return ""
+}
+
+func (m *LeaseWorkRequest) GetCapacity() int32 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *LeaseWorkRequest) GetResources() *WorkerResources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+type LeaseWorkResponse struct {
+	Jobs                 []*WorkRequest `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	LeaseDuration        int64          `protobuf:"varint,2,opt,name=leaseDuration,proto3" json:"leaseDuration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *LeaseWorkResponse) Reset()         { *m = LeaseWorkResponse{} }
+func (m *LeaseWorkResponse) String() string { return proto.CompactTextString(m) }
+func (*LeaseWorkResponse) ProtoMessage()    {}
+func (*LeaseWorkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{42}
+}
+
+func (m *LeaseWorkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaseWorkResponse.Unmarshal(m, b)
+}
+func (m *LeaseWorkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaseWorkResponse.Marshal(b, m, deterministic)
+}
+func (m *LeaseWorkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseWorkResponse.Merge(m, src)
+}
+func (m *LeaseWorkResponse) XXX_Size() int {
+	return xxx_messageInfo_LeaseWorkResponse.Size(m)
+}
+func (m *LeaseWorkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseWorkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseWorkResponse proto.InternalMessageInfo
+
+func (m *LeaseWorkResponse) GetJobs() []*WorkRequest {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+func (m *LeaseWorkResponse) GetLeaseDuration() int64 {
+	if m != nil {
+		return m.LeaseDuration
+	}
+	return 0
+}
+
+type Lease struct {
+	JobID                int32    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	Attempt              int32    `protobuf:"varint,2,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Lease) Reset()         { *m = Lease{} }
+func (m *Lease) String() string { return proto.CompactTextString(m) }
+func (*Lease) ProtoMessage()    {}
+func (*Lease) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{43}
+}
+
+func (m *Lease) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Lease.Unmarshal(m, b)
+}
+func (m *Lease) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Lease.Marshal(b, m, deterministic)
+}
+func (m *Lease) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Lease.Merge(m, src)
+}
+func (m *Lease) XXX_Size() int {
+	return xxx_messageInfo_Lease.Size(m)
+}
+func (m *Lease) XXX_DiscardUnknown() {
+	xxx_messageInfo_Lease.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Lease proto.InternalMessageInfo
+
+func (m *Lease) GetJobID() int32 {
+	if m != nil {
+		return m.JobID
+	}
+	return 0
+}
+
+func (m *Lease) GetAttempt() int32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+type RenewLeaseRequest struct {
+	Worker               string           `protobuf:"bytes,1,opt,name=worker,proto3" json:"worker,omitempty"`
+	Leases               []*Lease         `protobuf:"bytes,2,rep,name=leases,proto3" json:"leases,omitempty"`
+	Resources            *WorkerResources `protobuf:"bytes,3,opt,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RenewLeaseRequest) Reset()         { *m = RenewLeaseRequest{} }
+func (m *RenewLeaseRequest) String() string { return proto.CompactTextString(m) }
+func (*RenewLeaseRequest) ProtoMessage()    {}
+func (*RenewLeaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{44}
+}
+
+func (m *RenewLeaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenewLeaseRequest.Unmarshal(m, b)
+}
+func (m *RenewLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenewLeaseRequest.Marshal(b, m, deterministic)
+}
+func (m *RenewLeaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenewLeaseRequest.Merge(m, src)
+}
+func (m *RenewLeaseRequest) XXX_Size() int {
+	return xxx_messageInfo_RenewLeaseRequest.Size(m)
+}
+func (m *RenewLeaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenewLeaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenewLeaseRequest proto.InternalMessageInfo
+
+func (m *RenewLeaseRequest) GetWorker() string {
+	if m != nil {
+		return m.Worker
+	}
+	return ""
+}
+
+func (m *RenewLeaseRequest) GetLeases() []*Lease {
+	if m != nil {
+		return m.Leases
+	}
+	return nil
+}
+
+func (m *RenewLeaseRequest) GetResources() *WorkerResources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+type RenewLeaseResponse struct {
+	Stop                 []int32  `protobuf:"varint,1,rep,packed,name=stop,proto3" json:"stop,omitempty"`
+	LeaseDuration        int64    `protobuf:"varint,2,opt,name=leaseDuration,proto3" json:"leaseDuration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenewLeaseResponse) Reset()         { *m = RenewLeaseResponse{} }
+func (m *RenewLeaseResponse) String() string { return proto.CompactTextString(m) }
+func (*RenewLeaseResponse) ProtoMessage()    {}
+func (*RenewLeaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{45}
+}
+
+func (m *RenewLeaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenewLeaseResponse.Unmarshal(m, b)
+}
+func (m *RenewLeaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenewLeaseResponse.Marshal(b, m, deterministic)
+}
+func (m *RenewLeaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenewLeaseResponse.Merge(m, src)
+}
+func (m *RenewLeaseResponse) XXX_Size() int {
+	return xxx_messageInfo_RenewLeaseResponse.Size(m)
+}
+func (m *RenewLeaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenewLeaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenewLeaseResponse proto.InternalMessageInfo
+
+func (m *RenewLeaseResponse) GetStop() []int32 {
+	if m != nil {
+		return m.Stop
+	}
+	return nil
+}
+
+func (m *RenewLeaseResponse) GetLeaseDuration() int64 {
+	if m != nil {
+		return m.LeaseDuration
+	}
+	return 0
+}
+
+// Session service (workerMessage/commanderMessage), for workers which only
+// make outbound connections. The worker says hello on a Connect stream and
+// everything else the commander would dial in to deliver is sent down it.
+// Requests carry an id, replies carry the id they answer in replyTo.
+type WorkerMessage struct {
+	Id        int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReplyTo   int64  `protobuf:"varint,2,opt,name=replyTo,proto3" json:"replyTo,omitempty"`
+	ErrorCode int32  `protobuf:"varint,3,opt,name=errorCode,proto3" json:"errorCode,omitempty"`
+	Error     string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	End       bool   `protobuf:"varint,5,opt,name=end,proto3" json:"end,omitempty"`
+	// Types that are valid to be assigned to Message:
+	//	*WorkerMessage_Hello
+	//	*WorkerMessage_Pong
+	//	*WorkerMessage_Work
+	//	*WorkerMessage_Status
+	//	*WorkerMessage_Output
+	Message              isWorkerMessage_Message `protobuf_oneof:"message"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *WorkerMessage) Reset()         { *m = WorkerMessage{} }
+func (m *WorkerMessage) String() string { return proto.CompactTextString(m) }
+func (*WorkerMessage) ProtoMessage()    {}
+func (*WorkerMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{46}
+}
+
+func (m *WorkerMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkerMessage.Unmarshal(m, b)
+}
+func (m *WorkerMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkerMessage.Marshal(b, m, deterministic)
+}
+func (m *WorkerMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkerMessage.Merge(m, src)
+}
+func (m *WorkerMessage) XXX_Size() int {
+	return xxx_messageInfo_WorkerMessage.Size(m)
+}
+func (m *WorkerMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkerMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkerMessage proto.InternalMessageInfo
+
+func (m *WorkerMessage) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *WorkerMessage) GetReplyTo() int64 {
+	if m != nil {
+		return m.ReplyTo
+	}
+	return 0
+}
+
+func (m *WorkerMessage) GetErrorCode() int32 {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return 0
+}
+
+func (m *WorkerMessage) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *WorkerMessage) GetEnd() bool {
+	if m != nil {
+		return m.End
+	}
+	return false
+}
+
+type isWorkerMessage_Message interface {
+	isWorkerMessage_Message()
+}
+
+type WorkerMessage_Hello struct {
+	Hello *HelloRequest `protobuf:"bytes,6,opt,name=hello,proto3,oneof"`
+}
+
+type WorkerMessage_Pong struct {
+	Pong *Pong `protobuf:"bytes,7,opt,name=pong,proto3,oneof"`
+}
+
+type WorkerMessage_Work struct {
+	Work *WorkResponse `protobuf:"bytes,8,opt,name=work,proto3,oneof"`
+}
+
+type WorkerMessage_Status struct {
+	Status *JobStatusReport `protobuf:"bytes,9,opt,name=status,proto3,oneof"`
+}
+
+type WorkerMessage_Output struct {
+	Output *ResponseStdOut `protobuf:"bytes,10,opt,name=output,proto3,oneof"`
+}
+
+func (*WorkerMessage_Hello) isWorkerMessage_Message() {}
+
+func (*WorkerMessage_Pong) isWorkerMessage_Message() {}
+
+func (*WorkerMessage_Work) isWorkerMessage_Message() {}
+
+func (*WorkerMessage_Status) isWorkerMessage_Message() {}
+
+func (*WorkerMessage_Output) isWorkerMessage_Message() {}
+
+func (m *WorkerMessage) GetMessage() isWorkerMessage_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *WorkerMessage) GetHello() *HelloRequest {
+	if x, ok := m.GetMessage().(*WorkerMessage_Hello); ok {
+		return x.Hello
+	}
+	return nil
+}
+
+func (m *WorkerMessage) GetPong() *Pong {
+	if x, ok := m.GetMessage().(*WorkerMessage_Pong); ok {
+		return x.Pong
+	}
+	return nil
+}
+
+func (m *WorkerMessage) GetWork() *WorkResponse {
+	if x, ok := m.GetMessage().(*WorkerMessage_Work); ok {
+		return x.Work
+	}
+	return nil
+}
+
+func (m *WorkerMessage) GetStatus() *JobStatusReport {
+	if x, ok := m.GetMessage().(*WorkerMessage_Status); ok {
+		return x.Status
+	}
+	return nil
+}
+
+func (m *WorkerMessage) GetOutput() *ResponseStdOut {
+	if x, ok := m.GetMessage().(*WorkerMessage_Output); ok {
+		return x.Output
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*WorkerMessage) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*WorkerMessage_Hello)(nil),
+		(*WorkerMessage_Pong)(nil),
+		(*WorkerMessage_Work)(nil),
+		(*WorkerMessage_Status)(nil),
+		(*WorkerMessage_Output)(nil),
+	}
+}
+
+type CommanderMessage struct {
+	Id        int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReplyTo   int64  `protobuf:"varint,2,opt,name=replyTo,proto3" json:"replyTo,omitempty"`
+	ErrorCode int32  `protobuf:"varint,3,opt,name=errorCode,proto3" json:"errorCode,omitempty"`
+	Error     string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	HangUp    bool   `protobuf:"varint,5,opt,name=hangUp,proto3" json:"hangUp,omitempty"`
+	// Types that are valid to be assigned to Message:
+	//	*CommanderMessage_Hello
+	//	*CommanderMessage_Ping
+	//	*CommanderMessage_Work
+	//	*CommanderMessage_Cancel
+	//	*CommanderMessage_Output
+	//	*CommanderMessage_Status
+	Message              isCommanderMessage_Message `protobuf_oneof:"message"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *CommanderMessage) Reset()         { *m = CommanderMessage{} }
+func (m *CommanderMessage) String() string { return proto.CompactTextString(m) }
+func (*CommanderMessage) ProtoMessage()    {}
+func (*CommanderMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{47}
+}
+
+func (m *CommanderMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommanderMessage.Unmarshal(m, b)
+}
+func (m *CommanderMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommanderMessage.Marshal(b, m, deterministic)
+}
+func (m *CommanderMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommanderMessage.Merge(m, src)
+}
+func (m *CommanderMessage) XXX_Size() int {
+	return xxx_messageInfo_CommanderMessage.Size(m)
+}
+func (m *CommanderMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommanderMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommanderMessage proto.InternalMessageInfo
+
+func (m *CommanderMessage) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *CommanderMessage) GetReplyTo() int64 {
+	if m != nil {
+		return m.ReplyTo
+	}
+	return 0
+}
+
+func (m *CommanderMessage) GetErrorCode() int32 {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return 0
+}
+
+func (m *CommanderMessage) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CommanderMessage) GetHangUp() bool {
+	if m != nil {
+		return m.HangUp
+	}
+	return false
+}
+
+type isCommanderMessage_Message interface {
+	isCommanderMessage_Message()
+}
+
+type CommanderMessage_Hello struct {
+	Hello *HelloResponse `protobuf:"bytes,6,opt,name=hello,proto3,oneof"`
+}
+
+type CommanderMessage_Ping struct {
+	Ping *Ping `protobuf:"bytes,7,opt,name=ping,proto3,oneof"`
+}
+
+type CommanderMessage_Work struct {
+	Work *WorkRequest `protobuf:"bytes,8,opt,name=work,proto3,oneof"`
+}
+
+type CommanderMessage_Cancel struct {
+	Cancel *CancelJobRequest `protobuf:"bytes,9,opt,name=cancel,proto3,oneof"`
+}
+
+type CommanderMessage_Output struct {
+	Output *RequestStdOut `protobuf:"bytes,10,opt,name=output,proto3,oneof"`
+}
+
+type CommanderMessage_Status struct {
+	Status *JobStatusAck `protobuf:"bytes,11,opt,name=status,proto3,oneof"`
+}
+
+func (*CommanderMessage_Hello) isCommanderMessage_Message() {}
+
+func (*CommanderMessage_Ping) isCommanderMessage_Message() {}
+
+func (*CommanderMessage_Work) isCommanderMessage_Message() {}
+
+func (*CommanderMessage_Cancel) isCommanderMessage_Message() {}
+
+func (*CommanderMessage_Output) isCommanderMessage_Message() {}
+
+func (*CommanderMessage_Status) isCommanderMessage_Message() {}
+
+func (m *CommanderMessage) GetMessage() isCommanderMessage_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *CommanderMessage) GetHello() *HelloResponse {
+	if x, ok := m.GetMessage().(*CommanderMessage_Hello); ok {
+		return x.Hello
+	}
+	return nil
+}
+
+func (m *CommanderMessage) GetPing() *Ping {
+	if x, ok := m.GetMessage().(*CommanderMessage_Ping); ok {
+		return x.Ping
+	}
+	return nil
+}
+
+func (m *CommanderMessage) GetWork() *WorkRequest {
+	if x, ok := m.GetMessage().(*CommanderMessage_Work); ok {
+		return x.Work
+	}
+	return nil
+}
+
+func (m *CommanderMessage) GetCancel() *CancelJobRequest {
+	if x, ok := m.GetMessage().(*CommanderMessage_Cancel); ok {
+		return x.Cancel
+	}
+	return nil
+}
+
+func (m *CommanderMessage) GetOutput() *RequestStdOut {
+	if x, ok := m.GetMessage().(*CommanderMessage_Output); ok {
+		return x.Output
+	}
+	return nil
+}
+
+func (m *CommanderMessage) GetStatus() *JobStatusAck {
+	if x, ok := m.GetMessage().(*CommanderMessage_Status); ok {
+		return x.Status
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*CommanderMessage) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*CommanderMessage_Hello)(nil),
+		(*CommanderMessage_Ping)(nil),
+		(*CommanderMessage_Work)(nil),
+		(*CommanderMessage_Cancel)(nil),
+		(*CommanderMessage_Output)(nil),
+		(*CommanderMessage_Status)(nil),
+	}
+}
+
+type CreateScheduleRequest struct {
+	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cron                 string            `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"`
+	TimeZone             string            `protobuf:"bytes,3,opt,name=timeZone,proto3" json:"timeZone,omitempty"`
+	Overlap              OverlapPolicy     `protobuf:"varint,4,opt,name=overlap,proto3,enum=messages.OverlapPolicy" json:"overlap,omitempty"`
+	CatchUp              bool              `protobuf:"varint,5,opt,name=catchUp,proto3" json:"catchUp,omitempty"`
+	Job                  *SubmitJobRequest `protobuf:"bytes,6,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CreateScheduleRequest) Reset()         { *m = CreateScheduleRequest{} }
+func (m *CreateScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateScheduleRequest) ProtoMessage()    {}
+func (*CreateScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{48}
+}
+
+func (m *CreateScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateScheduleRequest.Unmarshal(m, b)
+}
+func (m *CreateScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateScheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateScheduleRequest.Merge(m, src)
+}
+func (m *CreateScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateScheduleRequest.Size(m)
+}
+func (m *CreateScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateScheduleRequest proto.InternalMessageInfo
+
+func (m *CreateScheduleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateScheduleRequest) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *CreateScheduleRequest) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+func (m *CreateScheduleRequest) GetOverlap() OverlapPolicy {
+	if m != nil {
+		return m.Overlap
+	}
+	return OverlapPolicy_OVERLAP_ALLOW
+}
+
+func (m *CreateScheduleRequest) GetCatchUp() bool {
+	if m != nil {
+		return m.CatchUp
+	}
+	return false
+}
+
+func (m *CreateScheduleRequest) GetJob() *SubmitJobRequest {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type ScheduleInfo struct {
+	ScheduleID           int32         `protobuf:"varint,1,opt,name=scheduleID,proto3" json:"scheduleID,omitempty"`
+	Name                 string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Cron                 string        `protobuf:"bytes,3,opt,name=cron,proto3" json:"cron,omitempty"`
+	TimeZone             string        `protobuf:"bytes,4,opt,name=timeZone,proto3" json:"timeZone,omitempty"`
+	Overlap              OverlapPolicy `protobuf:"varint,5,opt,name=overlap,proto3,enum=messages.OverlapPolicy" json:"overlap,omitempty"`
+	CatchUp              bool          `protobuf:"varint,6,opt,name=catchUp,proto3" json:"catchUp,omitempty"`
+	Job                  *JobInfo      `protobuf:"bytes,7,opt,name=job,proto3" json:"job,omitempty"`
+	Created              int64         `protobuf:"varint,8,opt,name=created,proto3" json:"created,omitempty"`
+	LastRun              int64         `protobuf:"varint,9,opt,name=lastRun,proto3" json:"lastRun,omitempty"`
+	NextRun              int64         `protobuf:"varint,10,opt,name=nextRun,proto3" json:"nextRun,omitempty"`
+	Jobs                 []int32       `protobuf:"varint,11,rep,packed,name=jobs,proto3" json:"jobs,omitempty"`
+	Skipped              int32         `protobuf:"varint,12,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Missed               int32         `protobuf:"varint,13,opt,name=missed,proto3" json:"missed,omitempty"`
+	MissedAt             []int64       `protobuf:"varint,14,rep,packed,name=missedAt,proto3" json:"missedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ScheduleInfo) Reset()         { *m = ScheduleInfo{} }
+func (m *ScheduleInfo) String() string { return proto.CompactTextString(m) }
+func (*ScheduleInfo) ProtoMessage()    {}
+func (*ScheduleInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{49}
+}
+
+func (m *ScheduleInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScheduleInfo.Unmarshal(m, b)
+}
+func (m *ScheduleInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScheduleInfo.Marshal(b, m, deterministic)
+}
+func (m *ScheduleInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleInfo.Merge(m, src)
+}
+func (m *ScheduleInfo) XXX_Size() int {
+	return xxx_messageInfo_ScheduleInfo.Size(m)
+}
+func (m *ScheduleInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleInfo proto.InternalMessageInfo
+
+func (m *ScheduleInfo) GetScheduleID() int32 {
+	if m != nil {
+		return m.ScheduleID
+	}
+	return 0
+}
+
+func (m *ScheduleInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ScheduleInfo) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *ScheduleInfo) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+func (m *ScheduleInfo) GetOverlap() OverlapPolicy {
+	if m != nil {
+		return m.Overlap
+	}
+	return OverlapPolicy_OVERLAP_ALLOW
+}
+
+func (m *ScheduleInfo) GetCatchUp() bool {
+	if m != nil {
+		return m.CatchUp
+	}
+	return false
+}
+
+func (m *ScheduleInfo) GetJob() *JobInfo {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *ScheduleInfo) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *ScheduleInfo) GetLastRun() int64 {
+	if m != nil {
+		return m.LastRun
+	}
+	return 0
+}
+
+func (m *ScheduleInfo) GetNextRun() int64 {
+	if m != nil {
+		return m.NextRun
+	}
+	return 0
+}
+
+func (m *ScheduleInfo) GetJobs() []int32 {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+func (m *ScheduleInfo) GetSkipped() int32 {
+	if m != nil {
+		return m.Skipped
+	}
+	return 0
+}
+
+func (m *ScheduleInfo) GetMissed() int32 {
+	if m != nil {
+		return m.Missed
+	}
+	return 0
+}
+
+func (m *ScheduleInfo) GetMissedAt() []int64 {
+	if m != nil {
+		return m.MissedAt
+	}
+	return nil
+}
+
+type GetScheduleRequest struct {
+	ScheduleID           int32    `protobuf:"varint,1,opt,name=scheduleID,proto3" json:"scheduleID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetScheduleRequest) Reset()         { *m = GetScheduleRequest{} }
+func (m *GetScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*GetScheduleRequest) ProtoMessage()    {}
+func (*GetScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{50}
+}
+
+func (m *GetScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetScheduleRequest.Unmarshal(m, b)
+}
+func (m *GetScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetScheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *GetScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetScheduleRequest.Merge(m, src)
+}
+func (m *GetScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_GetScheduleRequest.Size(m)
+}
+func (m *GetScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetScheduleRequest proto.InternalMessageInfo
+
+func (m *GetScheduleRequest) GetScheduleID() int32 {
+	if m != nil {
+		return m.ScheduleID
+	}
+	return 0
+}
+
+type ListSchedulesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSchedulesRequest) Reset()         { *m = ListSchedulesRequest{} }
+func (m *ListSchedulesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSchedulesRequest) ProtoMessage()    {}
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{51}
+}
+
+func (m *ListSchedulesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSchedulesRequest.Unmarshal(m, b)
+}
+func (m *ListSchedulesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSchedulesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSchedulesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSchedulesRequest.Merge(m, src)
+}
+func (m *ListSchedulesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSchedulesRequest.Size(m)
+}
+func (m *ListSchedulesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSchedulesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSchedulesRequest proto.InternalMessageInfo
+
+type ListSchedulesResponse struct {
+	Schedules            []*ScheduleInfo `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListSchedulesResponse) Reset()         { *m = ListSchedulesResponse{} }
+func (m *ListSchedulesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSchedulesResponse) ProtoMessage()    {}
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{52}
+}
+
+func (m *ListSchedulesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSchedulesResponse.Unmarshal(m, b)
+}
+func (m *ListSchedulesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSchedulesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListSchedulesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSchedulesResponse.Merge(m, src)
+}
+func (m *ListSchedulesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListSchedulesResponse.Size(m)
+}
+func (m *ListSchedulesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSchedulesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSchedulesResponse proto.InternalMessageInfo
+
+func (m *ListSchedulesResponse) GetSchedules() []*ScheduleInfo {
+	if m != nil {
+		return m.Schedules
+	}
+	return nil
+}
+
+type RemoveScheduleRequest struct {
+	ScheduleID           int32    `protobuf:"varint,1,opt,name=scheduleID,proto3" json:"scheduleID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveScheduleRequest) Reset()         { *m = RemoveScheduleRequest{} }
+func (m *RemoveScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveScheduleRequest) ProtoMessage()    {}
+func (*RemoveScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{53}
+}
+
+func (m *RemoveScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveScheduleRequest.Unmarshal(m, b)
+}
+func (m *RemoveScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveScheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveScheduleRequest.Merge(m, src)
+}
+func (m *RemoveScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveScheduleRequest.Size(m)
+}
+func (m *RemoveScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveScheduleRequest proto.InternalMessageInfo
+
+func (m *RemoveScheduleRequest) GetScheduleID() int32 {
+	if m != nil {
+		return m.ScheduleID
+	}
+	return 0
+}
+
+type RemoveScheduleResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveScheduleResponse) Reset()         { *m = RemoveScheduleResponse{} }
+func (m *RemoveScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveScheduleResponse) ProtoMessage()    {}
+func (*RemoveScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3b30b43b8942386, []int{54}
+}
+
+func (m *RemoveScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveScheduleResponse.Unmarshal(m, b)
+}
+func (m *RemoveScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveScheduleResponse.Marshal(b, m, deterministic)
+}
+func (m *RemoveScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveScheduleResponse.Merge(m, src)
+}
+func (m *RemoveScheduleResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveScheduleResponse.Size(m)
+}
+func (m *RemoveScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveScheduleResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("messages.LabelOperator", LabelOperator_name, LabelOperator_value)
+	proto.RegisterEnum("messages.DispatchMode", DispatchMode_name, DispatchMode_value)
+	proto.RegisterEnum("messages.FailurePolicy", FailurePolicy_name, FailurePolicy_value)
+	proto.RegisterEnum("messages.OutputStream", OutputStream_name, OutputStream_value)
+	proto.RegisterEnum("messages.OverlapPolicy", OverlapPolicy_name, OverlapPolicy_value)
+	proto.RegisterType((*HelloRequest)(nil), "messages.helloRequest")
+	proto.RegisterMapType((map[string]string)(nil), "messages.helloRequest.LabelsEntry")
+	proto.RegisterType((*HelloResponse)(nil), "messages.helloResponse")
+	proto.RegisterType((*Ping)(nil), "messages.ping")
+	proto.RegisterType((*Pong)(nil), "messages.pong")
+	proto.RegisterType((*WorkerResources)(nil), "messages.workerResources")
+	proto.RegisterType((*Resources)(nil), "messages.resources")
+	proto.RegisterType((*LabelRequirement)(nil), "messages.labelRequirement")
+	proto.RegisterType((*Job)(nil), "messages.job")
+	proto.RegisterMapType((map[string]string)(nil), "messages.job.EnvEntry")
+	proto.RegisterMapType((map[string]string)(nil), "messages.job.LabelsEntry")
 	proto.RegisterType((*WorkRequest)(nil), "messages.workRequest")
 	proto.RegisterType((*WorkResponse)(nil), "messages.workResponse")
+	proto.RegisterType((*RetryPolicy)(nil), "messages.retryPolicy")
+	proto.RegisterType((*SubmitJobRequest)(nil), "messages.submitJobRequest")
+	proto.RegisterType((*SubmitJobResponse)(nil), "messages.submitJobResponse")
+	proto.RegisterType((*GetJobRequest)(nil), "messages.getJobRequest")
+	proto.RegisterType((*JobTransition)(nil), "messages.jobTransition")
+	proto.RegisterType((*JobAttempt)(nil), "messages.jobAttempt")
+	proto.RegisterType((*JobInfo)(nil), "messages.jobInfo")
+	proto.RegisterType((*FanOutStatus)(nil), "messages.fanOutStatus")
+	proto.RegisterType((*FanOutResult)(nil), "messages.fanOutResult")
+	proto.RegisterType((*ListJobsRequest)(nil), "messages.listJobsRequest")
+	proto.RegisterType((*ListJobsResponse)(nil), "messages.listJobsResponse")
+	proto.RegisterType((*CancelJobRequest)(nil), "messages.cancelJobRequest")
+	proto.RegisterType((*WorkflowJob)(nil), "messages.workflowJob")
+	proto.RegisterType((*SubmitWorkflowRequest)(nil), "messages.submitWorkflowRequest")
+	proto.RegisterType((*GetWorkflowRequest)(nil), "messages.getWorkflowRequest")
+	proto.RegisterType((*WorkflowInfo)(nil), "messages.workflowInfo")
+	proto.RegisterType((*ListWorkflowsRequest)(nil), "messages.listWorkflowsRequest")
+	proto.RegisterType((*ListWorkflowsResponse)(nil), "messages.listWorkflowsResponse")
+	proto.RegisterType((*CancelWorkflowRequest)(nil), "messages.cancelWorkflowRequest")
+	proto.RegisterType((*WorkerInfo)(nil), "messages.workerInfo")
+	proto.RegisterMapType((map[string]string)(nil), "messages.workerInfo.LabelsEntry")
+	proto.RegisterType((*ListWorkersRequest)(nil), "messages.listWorkersRequest")
+	proto.RegisterType((*ListWorkersResponse)(nil), "messages.listWorkersResponse")
+	proto.RegisterType((*RemoveWorkerRequest)(nil), "messages.removeWorkerRequest")
+	proto.RegisterType((*RemoveWorkerResponse)(nil), "messages.removeWorkerResponse")
+	proto.RegisterType((*TenantInfo)(nil), "messages.tenantInfo")
+	proto.RegisterType((*ListTenantsRequest)(nil), "messages.listTenantsRequest")
+	proto.RegisterType((*ListTenantsResponse)(nil), "messages.listTenantsResponse")
+	proto.RegisterType((*JobStatusReport)(nil), "messages.jobStatusReport")
+	proto.RegisterType((*JobStatusAck)(nil), "messages.jobStatusAck")
 	proto.RegisterType((*RequestStdOut)(nil), "messages.requestStdOut")
 	proto.RegisterType((*ResponseStdOut)(nil), "messages.responseStdOut")
+	proto.RegisterType((*LeaseWorkRequest)(nil), "messages.leaseWorkRequest")
+	proto.RegisterType((*LeaseWorkResponse)(nil), "messages.leaseWorkResponse")
+	proto.RegisterType((*Lease)(nil), "messages.lease")
+	proto.RegisterType((*RenewLeaseRequest)(nil), "messages.renewLeaseRequest")
+	proto.RegisterType((*RenewLeaseResponse)(nil), "messages.renewLeaseResponse")
+	proto.RegisterType((*WorkerMessage)(nil), "messages.workerMessage")
+	proto.RegisterType((*CommanderMessage)(nil), "messages.commanderMessage")
+	proto.RegisterType((*CreateScheduleRequest)(nil), "messages.createScheduleRequest")
+	proto.RegisterType((*ScheduleInfo)(nil), "messages.scheduleInfo")
+	proto.RegisterType((*GetScheduleRequest)(nil), "messages.getScheduleRequest")
+	proto.RegisterType((*ListSchedulesRequest)(nil), "messages.listSchedulesRequest")
+	proto.RegisterType((*ListSchedulesResponse)(nil), "messages.listSchedulesResponse")
+	proto.RegisterType((*RemoveScheduleRequest)(nil), "messages.removeScheduleRequest")
+	proto.RegisterType((*RemoveScheduleResponse)(nil), "messages.removeScheduleResponse")
+}
+
+func init() {
+	proto.RegisterFile("internal/src/pbMessages/messages.proto", fileDescriptor_d3b30b43b8942386)
+}
+
+var fileDescriptor_d3b30b43b8942386 = []byte{
+	// 3460 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x3a, 0x4d, 0x6f, 0x1b, 0xd7,
+	0xb5, 0x1a, 0x7e, 0xf3, 0x90, 0x94, 0xa8, 0x6b, 0x5b, 0x9e, 0xd0, 0x5f, 0x7a, 0xf3, 0x9c, 0x3c,
+	0xc5, 0xc9, 0x93, 0x1d, 0xe6, 0xc3, 0x49, 0xf0, 0xf0, 0x00, 0x59, 0x62, 0x62, 0xd9, 0xb2, 0xe4,
+	0x37, 0x94, 0xed, 0x87, 0x74, 0x11, 0x0c, 0x39, 0x97, 0xd2, 0xc8, 0xe4, 0x0c, 0x3d, 0x33, 0x94,
+	0xad, 0x6e, 0x0a, 0x14, 0x68, 0xd0, 0x4d, 0x77, 0xdd, 0x76, 0x51, 0xa0, 0xcb, 0x02, 0x05, 0xba,
+	0xe8, 0x2f, 0x28, 0xba, 0xe8, 0xaa, 0xab, 0x2c, 0xbb, 0xe9, 0xaa, 0xbf, 0xa0, 0xdd, 0x14, 0x28,
+	0xce, 0xfd, 0x9a, 0x3b, 0xa3, 0xa1, 0x2c, 0x37, 0x41, 0x77, 0x73, 0xce, 0x3d, 0xf7, 0xde, 0x73,
+	0xcf, 0xf7, 0x39, 0x24, 0xbc, 0xe3, 0xf9, 0x31, 0x0d, 0x7d, 0x67, 0x7c, 0x3b, 0x0a, 0x87, 0xb7,
+	0xa7, 0x83, 0x47, 0x34, 0x8a, 0x9c, 0x03, 0x1a, 0xdd, 0x9e, 0x88, 0x8f, 0xf5, 0x69, 0x18, 0xc4,
+	0x01, 0xa9, 0x49, 0xd8, 0xfa, 0x69, 0x11, 0x9a, 0x87, 0x74, 0x3c, 0x0e, 0x6c, 0xfa, 0x62, 0x46,
+	0xa3, 0x98, 0x98, 0x50, 0x3d, 0xa6, 0x61, 0xe4, 0x05, 0xbe, 0x69, 0xac, 0x1a, 0x6b, 0x65, 0x5b,
+	0x82, 0x64, 0x11, 0x0a, 0xde, 0xd4, 0x2c, 0xac, 0x1a, 0x6b, 0x75, 0xbb, 0xe0, 0x4d, 0x09, 0x81,
+	0xd2, 0xe8, 0x85, 0xeb, 0x9b, 0x45, 0x86, 0x61, 0xdf, 0xe4, 0x7d, 0x58, 0x8e, 0x66, 0xd3, 0x69,
+	0x10, 0xc6, 0xd4, 0x7d, 0xca, 0xf7, 0x45, 0x66, 0x69, 0xb5, 0xb8, 0x56, 0xb6, 0x4f, 0x2f, 0x90,
+	0x0e, 0xd4, 0x46, 0xd4, 0x89, 0x67, 0x21, 0x8d, 0xcc, 0xf2, 0x6a, 0x71, 0xad, 0x6e, 0x2b, 0x18,
+	0x4f, 0x9f, 0x04, 0x2e, 0x35, 0x2b, 0xfc, 0x74, 0xfc, 0x66, 0x1c, 0xb8, 0x66, 0x55, 0x70, 0xe0,
+	0x92, 0xab, 0x50, 0x77, 0x5c, 0x37, 0xa4, 0x51, 0x44, 0x23, 0xb3, 0xc6, 0x0e, 0x48, 0x10, 0xe4,
+	0x73, 0xa8, 0x8c, 0x9d, 0x01, 0x1d, 0x47, 0x66, 0x7d, 0xb5, 0xb8, 0xd6, 0xe8, 0x5a, 0xeb, 0x4a,
+	0x0a, 0xfa, 0x8b, 0xd7, 0x77, 0x18, 0x51, 0xcf, 0x8f, 0xc3, 0x13, 0x5b, 0xec, 0x20, 0x77, 0xa1,
+	0x1e, 0xd2, 0x28, 0x98, 0x85, 0x43, 0x1a, 0x99, 0xb0, 0x6a, 0xac, 0x35, 0xba, 0x6f, 0x25, 0xdb,
+	0x5f, 0x06, 0xe1, 0x73, 0x1a, 0xda, 0x92, 0xc0, 0x4e, 0x68, 0x3b, 0x9f, 0x41, 0x43, 0x3b, 0x8f,
+	0xb4, 0xa1, 0xf8, 0x9c, 0x9e, 0x30, 0x49, 0xd6, 0x6d, 0xfc, 0x24, 0x17, 0xa1, 0x7c, 0xec, 0x8c,
+	0x67, 0x54, 0x08, 0x92, 0x03, 0x9f, 0x17, 0x3e, 0x35, 0xac, 0x1e, 0xb4, 0x04, 0x5f, 0xd1, 0x34,
+	0xf0, 0x23, 0x7a, 0x86, 0x2a, 0x74, 0xc1, 0x15, 0xd2, 0x82, 0xb3, 0x3a, 0x50, 0x9a, 0x7a, 0xfe,
+	0x01, 0x0a, 0xd0, 0x77, 0x26, 0x54, 0xdc, 0xcd, 0xbe, 0xad, 0x3e, 0x94, 0xa6, 0x41, 0xfe, 0x5a,
+	0xfa, 0xc9, 0x85, 0xf3, 0x3f, 0xd9, 0xfa, 0x4d, 0x01, 0x96, 0x32, 0xcb, 0x78, 0xc1, 0x70, 0x3a,
+	0x8b, 0x04, 0xdf, 0xec, 0x9b, 0xac, 0x42, 0x23, 0x0e, 0x62, 0x67, 0xfc, 0x88, 0x4e, 0x82, 0xf0,
+	0x84, 0x5d, 0x51, 0xb4, 0x75, 0x14, 0xb9, 0x0e, 0x30, 0x0a, 0x29, 0x15, 0x04, 0x45, 0x46, 0xa0,
+	0x61, 0x50, 0xdf, 0x8c, 0x7c, 0xcb, 0x8b, 0x9e, 0x9b, 0x25, 0xb6, 0x9c, 0x20, 0x98, 0x50, 0x42,
+	0x4a, 0xd9, 0x62, 0x99, 0x2d, 0x2a, 0x18, 0x2d, 0x27, 0x88, 0x84, 0x2d, 0x15, 0x02, 0xc6, 0x9f,
+	0x13, 0x0e, 0x0f, 0x85, 0x2d, 0xb1, 0x6f, 0xd4, 0x4c, 0x34, 0x0e, 0x62, 0xb4, 0x24, 0x64, 0x9a,
+	0x03, 0x78, 0x27, 0xfb, 0x78, 0x12, 0x51, 0xd7, 0xac, 0xb3, 0x95, 0x04, 0x41, 0x56, 0xa0, 0xf2,
+	0x62, 0x46, 0x67, 0xd4, 0x65, 0x46, 0x52, 0xb6, 0x05, 0x84, 0xbb, 0xd8, 0x57, 0xdf, 0xfb, 0x21,
+	0x35, 0x1b, 0x7c, 0x97, 0x42, 0x58, 0x0f, 0x35, 0x51, 0xa7, 0x44, 0x65, 0x08, 0x51, 0xad, 0x40,
+	0x65, 0xa2, 0x4b, 0x49, 0x40, 0x48, 0xeb, 0xe2, 0xf3, 0xb8, 0x68, 0xd8, 0xb7, 0xf5, 0x02, 0xda,
+	0xcc, 0x68, 0xd1, 0x9c, 0xbd, 0x90, 0x4e, 0xa8, 0x1f, 0xe7, 0x98, 0xdd, 0x87, 0x50, 0x0b, 0xa6,
+	0x34, 0x74, 0xe2, 0x20, 0x64, 0x67, 0x2e, 0x76, 0x2f, 0x27, 0xca, 0x65, 0xfb, 0xf7, 0xc4, 0xb2,
+	0xad, 0x08, 0x91, 0x0d, 0x66, 0x9e, 0x91, 0x59, 0x64, 0x46, 0x26, 0x20, 0xeb, 0x0f, 0x45, 0x28,
+	0x1e, 0x05, 0x03, 0x34, 0xd0, 0x61, 0x30, 0x99, 0x38, 0xbe, 0x2b, 0xae, 0x92, 0x20, 0x97, 0xef,
+	0x81, 0x34, 0x4e, 0xf6, 0x4d, 0xd6, 0xa0, 0x48, 0xfd, 0x63, 0x76, 0x54, 0xa3, 0xbb, 0x92, 0xdc,
+	0x7e, 0x14, 0x0c, 0xd6, 0x7b, 0xfe, 0x31, 0x77, 0x40, 0x24, 0x41, 0x3b, 0x40, 0x83, 0xf2, 0xfc,
+	0x83, 0x2d, 0x2f, 0x64, 0x8a, 0xae, 0xdb, 0x1a, 0x06, 0xef, 0x8d, 0xbd, 0x09, 0x0d, 0x66, 0xb1,
+	0x50, 0xb4, 0x04, 0x51, 0xee, 0xcf, 0xbd, 0xf1, 0xf8, 0xcb, 0xd0, 0x19, 0xf2, 0xd0, 0x51, 0xb4,
+	0x13, 0x04, 0xf9, 0x40, 0x45, 0x84, 0xea, 0x6a, 0x31, 0x6d, 0xdf, 0xc8, 0x44, 0x5e, 0x20, 0xf8,
+	0x40, 0xf7, 0x8a, 0x1a, 0xf3, 0x8a, 0x0b, 0xc9, 0xae, 0x30, 0xc7, 0x1f, 0xc8, 0x27, 0x50, 0x8b,
+	0xe8, 0x98, 0x0e, 0x51, 0xd4, 0x3c, 0xf2, 0x74, 0x32, 0xa2, 0xd6, 0x54, 0x65, 0x2b, 0xda, 0xce,
+	0x27, 0x50, 0x93, 0x62, 0x78, 0x93, 0xb8, 0xf1, 0x5d, 0x42, 0x4e, 0x08, 0x0d, 0x14, 0xab, 0x8c,
+	0xfd, 0x17, 0xa1, 0x7c, 0x14, 0x0c, 0xb6, 0xb7, 0x84, 0xdb, 0x72, 0x00, 0x0f, 0x3c, 0x0a, 0x06,
+	0x6c, 0x73, 0xd3, 0xc6, 0x4f, 0xf2, 0x1f, 0x50, 0x8a, 0xa6, 0x74, 0xc8, 0xcc, 0xb0, 0xd1, 0x6d,
+	0xa5, 0xa4, 0x68, 0xb3, 0x25, 0x54, 0x91, 0x13, 0xc7, 0x74, 0x32, 0x8d, 0x99, 0xfe, 0xca, 0xb6,
+	0x04, 0xad, 0x6f, 0x0d, 0x68, 0xf2, 0x4b, 0x45, 0x98, 0xcb, 0xbf, 0x75, 0x05, 0x2a, 0xc1, 0x2c,
+	0x9e, 0xce, 0x62, 0xc1, 0xb5, 0x80, 0xd0, 0xcb, 0xe9, 0x2b, 0x2f, 0xde, 0xc4, 0xdc, 0x50, 0x64,
+	0x1b, 0x14, 0x8c, 0x7b, 0xa2, 0xd8, 0xa5, 0xa1, 0xb4, 0x19, 0x01, 0x31, 0x1f, 0x8e, 0x9d, 0x30,
+	0xde, 0xf7, 0x26, 0x54, 0x58, 0x4c, 0x82, 0x40, 0x56, 0xa9, 0xef, 0xb2, 0x35, 0x6e, 0x31, 0x12,
+	0x64, 0xe7, 0x79, 0x07, 0xbe, 0x33, 0x16, 0x71, 0x42, 0x40, 0xc8, 0x31, 0x0d, 0xc3, 0x20, 0x64,
+	0x06, 0x51, 0xb7, 0x39, 0x60, 0xfd, 0xc5, 0x80, 0x46, 0x48, 0xe3, 0xf0, 0xe4, 0x71, 0x30, 0xf6,
+	0x86, 0x27, 0x18, 0xef, 0x26, 0xce, 0xab, 0x0d, 0xfe, 0x6c, 0x19, 0x0a, 0x75, 0x14, 0x52, 0x0c,
+	0x9c, 0xe1, 0xf3, 0x60, 0x34, 0xba, 0xe7, 0x44, 0x54, 0x46, 0x44, 0x0d, 0x85, 0x9e, 0x20, 0xc0,
+	0x4d, 0x67, 0x2a, 0x23, 0x62, 0x82, 0x41, 0x0e, 0x8f, 0xbc, 0x38, 0xa6, 0xfc, 0xc5, 0x86, 0x2d,
+	0x20, 0xb2, 0x0e, 0x84, 0xb1, 0xe2, 0x0c, 0xc6, 0xb4, 0x27, 0xc4, 0xc3, 0x73, 0x6c, 0xd9, 0xce,
+	0x59, 0x21, 0x6b, 0xb0, 0xe4, 0x7a, 0xa3, 0x11, 0x0d, 0xa9, 0x1f, 0x3f, 0x63, 0xb1, 0x9c, 0xc9,
+	0xa2, 0x66, 0x67, 0xd1, 0xd6, 0x1f, 0x0b, 0xd0, 0x8e, 0x66, 0x83, 0x89, 0x17, 0x3f, 0x08, 0x06,
+	0xd2, 0x70, 0xde, 0x83, 0x32, 0x3b, 0x94, 0x09, 0xb7, 0xd1, 0xbd, 0xa4, 0x7b, 0x88, 0x12, 0x88,
+	0xcd, 0x69, 0x94, 0xf5, 0x54, 0xe6, 0x5b, 0x4f, 0x17, 0x6a, 0xae, 0x17, 0x4d, 0x9d, 0x58, 0x84,
+	0xe8, 0x45, 0x3d, 0x5e, 0xc8, 0x95, 0x47, 0x81, 0x4b, 0x6d, 0x45, 0x87, 0x4a, 0x19, 0x06, 0x33,
+	0x3f, 0x96, 0xe1, 0x9b, 0x01, 0x68, 0x2e, 0xd3, 0xd0, 0x0b, 0x42, 0x2f, 0x3e, 0x11, 0xd1, 0x5b,
+	0xc1, 0xe4, 0x26, 0xb4, 0xe4, 0xf7, 0xe6, 0xd8, 0x89, 0x78, 0xa2, 0xaf, 0xdb, 0x69, 0x24, 0x8a,
+	0x38, 0xa6, 0xbe, 0xe3, 0xc7, 0x2c, 0x8e, 0xd7, 0x6d, 0x01, 0xe1, 0x7d, 0xe1, 0xcc, 0xdf, 0x88,
+	0xcd, 0x26, 0xd3, 0x0a, 0x07, 0x10, 0xeb, 0xd2, 0xb1, 0x73, 0x62, 0xb6, 0x38, 0x96, 0x01, 0xe8,
+	0x42, 0x71, 0x3c, 0x36, 0x17, 0x19, 0x0e, 0x3f, 0x1f, 0x94, 0x6a, 0x46, 0xbb, 0x6c, 0xbd, 0x0b,
+	0xcb, 0x9a, 0x2c, 0xcf, 0xf2, 0x07, 0xeb, 0x6d, 0x68, 0x1d, 0x50, 0x5d, 0xe6, 0xf9, 0x64, 0x7d,
+	0x68, 0x1d, 0x05, 0x83, 0xfd, 0xd0, 0xf1, 0x23, 0x2f, 0xc6, 0x52, 0x81, 0xf9, 0x84, 0x13, 0xab,
+	0x5c, 0x2c, 0x20, 0x8c, 0xd0, 0x18, 0x34, 0x85, 0xd1, 0xb1, 0x6f, 0xa4, 0x0d, 0xa9, 0x13, 0x05,
+	0xb2, 0xa6, 0x13, 0x90, 0xf5, 0x77, 0x03, 0xe0, 0x28, 0x18, 0x08, 0xbb, 0x45, 0x32, 0x7f, 0x36,
+	0x19, 0xd0, 0x50, 0x1e, 0xc9, 0x21, 0xc4, 0xf3, 0x3a, 0x40, 0xba, 0x2c, 0x87, 0x34, 0x16, 0x8a,
+	0x29, 0x16, 0x74, 0x57, 0x2e, 0xe5, 0xb8, 0x32, 0x77, 0xbd, 0x72, 0xbe, 0xeb, 0x55, 0x34, 0xd7,
+	0x4b, 0x3b, 0x78, 0xf5, 0x0c, 0x07, 0xaf, 0xa5, 0x1d, 0x7c, 0x15, 0x1a, 0xd3, 0x90, 0xe2, 0xa3,
+	0xa8, 0x7b, 0x4f, 0x1a, 0x88, 0x8e, 0xb2, 0x7e, 0x5d, 0x85, 0x2a, 0x4a, 0xd6, 0x1f, 0x05, 0x73,
+	0x02, 0x95, 0x96, 0x04, 0x0b, 0xf9, 0x49, 0xb0, 0xa8, 0x25, 0xc1, 0x44, 0x16, 0xa5, 0x94, 0x2c,
+	0x12, 0xd9, 0x95, 0x53, 0xb2, 0xfb, 0x00, 0xaa, 0x87, 0x5e, 0x14, 0x63, 0x29, 0x50, 0x61, 0xb9,
+	0xe4, 0x72, 0xca, 0x5f, 0x12, 0x45, 0xdb, 0x92, 0x2e, 0x25, 0xd6, 0xea, 0x5c, 0xb1, 0xd6, 0xf2,
+	0xc5, 0x5a, 0xd7, 0xc5, 0xaa, 0xe5, 0x59, 0x38, 0x23, 0xcf, 0x36, 0xb2, 0x79, 0x56, 0x85, 0x83,
+	0xe6, 0x39, 0xc2, 0xc1, 0x1d, 0xa8, 0x39, 0x32, 0x46, 0xb6, 0xd8, 0x13, 0x2f, 0xa6, 0x9e, 0x28,
+	0xac, 0xce, 0x56, 0x54, 0x78, 0xb9, 0x1f, 0xc4, 0xf7, 0xe8, 0x28, 0x08, 0xa9, 0xf0, 0xa9, 0x04,
+	0xa1, 0xc2, 0xcb, 0xd2, 0xfc, 0xf0, 0xd2, 0x81, 0xda, 0x98, 0x3a, 0x11, 0xed, 0xf9, 0xae, 0xd9,
+	0xe6, 0x95, 0xa2, 0x84, 0x53, 0xa1, 0x67, 0xf9, 0x4d, 0x43, 0x0f, 0xd1, 0x43, 0xcf, 0x0a, 0x54,
+	0xa6, 0x0e, 0x46, 0x4e, 0xf3, 0x02, 0x57, 0x35, 0x87, 0xc8, 0x3a, 0x54, 0x46, 0x8e, 0xbf, 0x37,
+	0x8b, 0xcd, 0x8b, 0x8c, 0x45, 0xed, 0x7c, 0x8e, 0xef, 0x33, 0x93, 0xb0, 0x05, 0x55, 0x2a, 0x84,
+	0x5d, 0x7a, 0x5d, 0x08, 0x5b, 0xc9, 0x0b, 0x61, 0x19, 0x33, 0xbf, 0x7c, 0xca, 0xcc, 0xb5, 0x20,
+	0x67, 0xa6, 0x82, 0x5c, 0x07, 0x6a, 0x68, 0x88, 0xa3, 0x71, 0xf0, 0xd2, 0x7c, 0x8b, 0xdf, 0x2d,
+	0x61, 0xd5, 0x44, 0x74, 0xb4, 0x26, 0xe2, 0x2a, 0xd4, 0x5d, 0x3a, 0xa5, 0xbe, 0x1b, 0xed, 0xf9,
+	0xe6, 0x15, 0x96, 0x6e, 0x12, 0x04, 0x9e, 0x16, 0x0d, 0x0f, 0xa9, 0x3b, 0x1b, 0x53, 0xf3, 0x2a,
+	0x3f, 0x4d, 0xc2, 0x49, 0x38, 0xbd, 0xa6, 0x87, 0x53, 0x11, 0x38, 0xaf, 0xab, 0xc0, 0x89, 0x37,
+	0xd0, 0x57, 0x53, 0x2f, 0xa4, 0xd1, 0x46, 0x6c, 0xde, 0xe0, 0xca, 0x57, 0x08, 0xeb, 0xcf, 0x06,
+	0x34, 0x75, 0x21, 0xe2, 0xb1, 0xac, 0x43, 0x90, 0x3e, 0xcb, 0x00, 0x34, 0x6c, 0xe4, 0xc9, 0xf3,
+	0x0f, 0x98, 0xcf, 0x96, 0x6d, 0x09, 0xe2, 0x4a, 0x38, 0xf3, 0x7d, 0x5c, 0xe1, 0xc1, 0x4a, 0x82,
+	0x78, 0x71, 0x34, 0x1b, 0x0e, 0x29, 0x75, 0xa9, 0x2b, 0x9c, 0x37, 0x41, 0xa0, 0x00, 0x47, 0x8e,
+	0x37, 0xa6, 0x2e, 0xf3, 0xdf, 0xb2, 0x2d, 0x20, 0xdc, 0x35, 0x74, 0xfc, 0x21, 0x1d, 0xe3, 0x52,
+	0x85, 0xef, 0x52, 0x08, 0x72, 0x07, 0xaa, 0x21, 0x8d, 0x66, 0xe3, 0x58, 0x56, 0xa4, 0xa7, 0x6c,
+	0xc1, 0x66, 0xcb, 0xb6, 0x24, 0xb3, 0xbe, 0x51, 0x0f, 0xe4, 0x2b, 0xf3, 0xab, 0xa7, 0xef, 0x2d,
+	0x14, 0xab, 0xd8, 0x50, 0xd6, 0xab, 0x9d, 0x65, 0x58, 0x1a, 0x7b, 0x11, 0x26, 0xa4, 0x48, 0x64,
+	0x24, 0xeb, 0x33, 0x68, 0x27, 0x28, 0x91, 0xcc, 0xde, 0x86, 0xd2, 0x51, 0x30, 0xc0, 0xe4, 0x83,
+	0xcf, 0x5b, 0x4e, 0x79, 0x23, 0x06, 0x55, 0x9b, 0x2d, 0x5b, 0x6b, 0xd0, 0xe6, 0x52, 0x79, 0x6d,
+	0x82, 0x9b, 0x40, 0x43, 0x5a, 0xe0, 0x83, 0x60, 0x90, 0xdb, 0xc9, 0xbe, 0x9f, 0x14, 0xac, 0xa9,
+	0xda, 0x3b, 0x5b, 0xb6, 0xf0, 0x62, 0x36, 0x65, 0xb2, 0x3c, 0x54, 0x27, 0x08, 0xeb, 0x04, 0x2e,
+	0xf1, 0x6d, 0xcf, 0xc4, 0xa5, 0x92, 0xbb, 0x77, 0x53, 0x0f, 0xbb, 0x94, 0xee, 0x94, 0x05, 0x77,
+	0xfc, 0x71, 0xe4, 0x63, 0xa8, 0x07, 0xfe, 0x17, 0x8e, 0x37, 0x9e, 0x85, 0xf4, 0x74, 0xf3, 0x35,
+	0xe2, 0x0b, 0x22, 0x28, 0x26, 0x94, 0xd6, 0x47, 0x40, 0x0e, 0xe8, 0xa9, 0x7b, 0x45, 0x6f, 0x84,
+	0x28, 0x25, 0x1a, 0x0d, 0x63, 0xfd, 0x4a, 0x94, 0xd7, 0x0c, 0xc4, 0xac, 0xf5, 0x9a, 0x0d, 0x9a,
+	0x49, 0x14, 0x52, 0x26, 0x91, 0xe2, 0xba, 0x78, 0x5e, 0xae, 0x95, 0xc2, 0x4b, 0x67, 0x2b, 0x7c,
+	0x05, 0x2e, 0xa2, 0xad, 0xc8, 0xd7, 0x29, 0x1b, 0x7a, 0x04, 0x97, 0x32, 0x78, 0x61, 0x48, 0x1f,
+	0x41, 0x5d, 0x32, 0x2d, 0x85, 0xbe, 0x72, 0x5a, 0xe8, 0xec, 0x86, 0x84, 0xd0, 0xba, 0x0b, 0x97,
+	0xb8, 0x5d, 0xbd, 0xa9, 0x18, 0xff, 0x5a, 0xe4, 0x04, 0x34, 0x64, 0x42, 0xe4, 0x93, 0xa7, 0x9a,
+	0x9a, 0x3c, 0xf1, 0x59, 0x98, 0x71, 0x6a, 0x16, 0x56, 0xd0, 0x66, 0x61, 0x69, 0x5f, 0xab, 0x2b,
+	0xc1, 0xde, 0x84, 0x96, 0x4f, 0x63, 0x3c, 0xbc, 0x87, 0x9e, 0x24, 0x2b, 0x81, 0x34, 0x12, 0x2b,
+	0x72, 0x36, 0xab, 0x1b, 0x06, 0x63, 0x31, 0x2f, 0x13, 0x91, 0x25, 0x8b, 0x4e, 0x0d, 0x83, 0x2a,
+	0x73, 0xa6, 0x68, 0x55, 0x6d, 0x8a, 0x96, 0x9a, 0x9a, 0xd5, 0xb3, 0x53, 0xb3, 0x4f, 0x55, 0x8f,
+	0x0c, 0x4c, 0xc8, 0xab, 0xd9, 0x19, 0x10, 0xca, 0xe3, 0xf5, 0x33, 0xb3, 0xc6, 0xf9, 0x07, 0x48,
+	0xe4, 0x36, 0xd4, 0x42, 0x1a, 0xd1, 0xf0, 0x98, 0xba, 0x66, 0x73, 0x7e, 0x8b, 0xad, 0x88, 0xf0,
+	0x55, 0xcc, 0xc6, 0x5a, 0x7c, 0xba, 0x84, 0xdf, 0xdf, 0xa5, 0x0b, 0xbe, 0x08, 0x44, 0xda, 0x1c,
+	0x0d, 0x95, 0x25, 0xf6, 0xe0, 0x42, 0x0a, 0x2b, 0xec, 0x70, 0x1d, 0xaa, 0xfc, 0x29, 0xd2, 0x0a,
+	0x2f, 0xe6, 0x09, 0xc8, 0x96, 0x44, 0xd6, 0xc7, 0x70, 0x21, 0xa4, 0x93, 0xe0, 0x98, 0x3e, 0x13,
+	0x02, 0xe0, 0xf6, 0x97, 0x35, 0x20, 0x6e, 0x60, 0x72, 0xb8, 0xea, 0xa2, 0x7f, 0xa4, 0xb7, 0xf1,
+	0xeb, 0xad, 0xdf, 0x19, 0x00, 0x3c, 0x37, 0x33, 0xbb, 0xcc, 0x0b, 0x7f, 0x18, 0xfb, 0xa9, 0x77,
+	0x70, 0xc8, 0x3b, 0x67, 0xc3, 0x16, 0xd0, 0x19, 0xa9, 0xcd, 0x84, 0xea, 0x4b, 0xc7, 0x8b, 0x71,
+	0x45, 0x34, 0xeb, 0x02, 0x44, 0xa1, 0xcd, 0xf0, 0x6d, 0xcc, 0xf6, 0x0c, 0x9b, 0x03, 0x88, 0x8d,
+	0x0e, 0x9d, 0x90, 0xf7, 0xcb, 0x86, 0xcd, 0x01, 0xb4, 0xab, 0x91, 0xe3, 0x85, 0x7d, 0xb6, 0x52,
+	0x65, 0x2b, 0x09, 0x42, 0x0a, 0x79, 0x9f, 0xf1, 0x9e, 0x15, 0xb2, 0xc2, 0x26, 0x42, 0xe6, 0x8f,
+	0xcc, 0x11, 0x72, 0xf2, 0x7a, 0x5b, 0x12, 0x59, 0xbf, 0x28, 0xc0, 0xd2, 0x51, 0x30, 0x10, 0x85,
+	0x13, 0xc5, 0x31, 0xf3, 0xf7, 0x94, 0x18, 0x65, 0x9b, 0x54, 0xd2, 0xda, 0x24, 0x3d, 0x59, 0x96,
+	0x4f, 0x17, 0xd8, 0x62, 0x6c, 0x51, 0x49, 0x8d, 0x2d, 0x92, 0xd1, 0x44, 0x75, 0xfe, 0x68, 0xa2,
+	0x96, 0xed, 0x5c, 0x92, 0x72, 0xbd, 0x9e, 0x5f, 0xae, 0x43, 0xa6, 0x5c, 0x97, 0x33, 0x97, 0x46,
+	0x7a, 0xe6, 0x72, 0x13, 0x9a, 0x4a, 0x3c, 0x1b, 0xc3, 0xe7, 0x73, 0x52, 0xeb, 0x4f, 0x0c, 0x68,
+	0x85, 0x5c, 0x31, 0xfd, 0xd8, 0xc5, 0xd2, 0x33, 0x5f, 0x86, 0x16, 0x34, 0xa3, 0xd8, 0x0d, 0x66,
+	0xf1, 0xde, 0x68, 0x14, 0xd1, 0x58, 0xb4, 0x90, 0x29, 0x9c, 0xa0, 0xa1, 0x61, 0x28, 0x68, 0x8a,
+	0x8a, 0x46, 0xe1, 0x58, 0xcd, 0x14, 0x8c, 0xb1, 0xb4, 0x2c, 0xb1, 0x59, 0x83, 0x80, 0xac, 0x1f,
+	0x1b, 0xb0, 0x18, 0x0a, 0x53, 0x38, 0x93, 0x11, 0x1c, 0x87, 0x3a, 0xb1, 0x23, 0xa3, 0x2e, 0x7e,
+	0x63, 0x75, 0x1d, 0xc5, 0x21, 0x75, 0x26, 0x22, 0x67, 0x69, 0x49, 0x82, 0xab, 0xa2, 0xcf, 0x56,
+	0x6d, 0x41, 0xc5, 0x14, 0xc6, 0x59, 0xe4, 0x2a, 0x16, 0x90, 0xf5, 0x23, 0x68, 0xb3, 0x9e, 0xe0,
+	0x99, 0x36, 0x1f, 0x4b, 0x8c, 0xc7, 0x48, 0x19, 0x4f, 0x07, 0x6a, 0x43, 0x67, 0xea, 0x0c, 0xb1,
+	0x42, 0xe7, 0x49, 0x54, 0xc1, 0xe9, 0xa8, 0x58, 0x7c, 0x83, 0xb1, 0xba, 0x0b, 0xcb, 0x1a, 0x03,
+	0xc2, 0x31, 0xce, 0xac, 0x3a, 0x64, 0x59, 0xc3, 0x48, 0x30, 0xcd, 0xb0, 0xfd, 0x5b, 0xb3, 0xd0,
+	0xc1, 0x06, 0x51, 0xa8, 0x29, 0x8d, 0xb4, 0xee, 0x42, 0x99, 0x21, 0xe6, 0x37, 0xb7, 0xd2, 0xa4,
+	0x0a, 0x69, 0x93, 0xfa, 0x99, 0x01, 0xcb, 0x21, 0xf5, 0xe9, 0xcb, 0x1d, 0xdc, 0xfe, 0x3a, 0x09,
+	0xfd, 0x17, 0x54, 0xd8, 0x35, 0x7c, 0x22, 0xdc, 0xe8, 0x2e, 0x25, 0x9c, 0x33, 0xbc, 0x2d, 0x96,
+	0xff, 0x75, 0x71, 0xed, 0x02, 0xd1, 0xd9, 0x11, 0xf2, 0x22, 0x50, 0x8a, 0xe2, 0x60, 0xca, 0xe4,
+	0x55, 0xb6, 0xd9, 0xf7, 0x39, 0x05, 0xf3, 0xb7, 0x02, 0xb4, 0xf8, 0x75, 0xe2, 0x47, 0x34, 0x11,
+	0xa2, 0x0d, 0x46, 0x8c, 0x35, 0x00, 0xc6, 0x53, 0x3a, 0x1d, 0x9f, 0xec, 0x07, 0xe2, 0x04, 0x09,
+	0xb2, 0x1e, 0x05, 0x3d, 0x52, 0x1b, 0x52, 0x26, 0x88, 0xc4, 0x79, 0x4b, 0xba, 0xf3, 0xb6, 0x71,
+	0x3a, 0xce, 0xbb, 0x87, 0x1a, 0x4e, 0xc1, 0x5d, 0xb2, 0x0e, 0x65, 0xf6, 0x7b, 0x90, 0x18, 0x94,
+	0xad, 0xe4, 0xff, 0x7c, 0x75, 0x7f, 0xc1, 0xe6, 0x64, 0xe4, 0x26, 0xff, 0x71, 0x87, 0x05, 0x98,
+	0x46, 0x77, 0x31, 0x21, 0x47, 0xec, 0xfd, 0x05, 0x9b, 0xad, 0x92, 0xf7, 0xa1, 0x84, 0xcf, 0x32,
+	0x6b, 0xd9, 0x43, 0xf5, 0x99, 0x2c, 0x52, 0x23, 0x4c, 0x3e, 0x54, 0x61, 0xb1, 0x9e, 0xd5, 0x45,
+	0x26, 0xde, 0xde, 0x5f, 0x50, 0x31, 0xb3, 0xab, 0x62, 0x20, 0xff, 0xe5, 0xcc, 0x4c, 0x65, 0x73,
+	0xcd, 0xad, 0x71, 0x0f, 0xa7, 0xbc, 0x57, 0x87, 0xaa, 0x20, 0xb2, 0x7e, 0x5b, 0x84, 0xb6, 0x18,
+	0xa1, 0xfc, 0xbb, 0x84, 0xbf, 0x02, 0x95, 0x43, 0xc7, 0x3f, 0x78, 0x32, 0x15, 0xf2, 0x17, 0x10,
+	0xb9, 0x9d, 0x56, 0xc1, 0xe5, 0x53, 0x2a, 0x50, 0xe2, 0xd2, 0x74, 0xe0, 0xe5, 0xea, 0xc0, 0x13,
+	0x3a, 0xc0, 0xac, 0xfa, 0x5e, 0x4a, 0x07, 0xf9, 0x5e, 0xac, 0x54, 0xf0, 0x11, 0x54, 0x78, 0x09,
+	0x6b, 0xd6, 0xb3, 0x0d, 0x4d, 0xb6, 0x65, 0x42, 0x79, 0x72, 0x1c, 0xfe, 0xd4, 0x91, 0xd2, 0xc1,
+	0x65, 0x5d, 0x07, 0x5a, 0x88, 0x4f, 0x54, 0x40, 0xee, 0x28, 0x5d, 0x37, 0xb2, 0xb6, 0xa1, 0x27,
+	0x8f, 0x44, 0xd1, 0xba, 0xd2, 0xbe, 0x35, 0xe0, 0xd2, 0x30, 0xa4, 0x4e, 0x4c, 0xfb, 0xa2, 0xa3,
+	0x97, 0x21, 0x21, 0xaf, 0x44, 0xc1, 0xdf, 0xbc, 0xc2, 0x40, 0x95, 0xcb, 0xf8, 0x8d, 0x41, 0x14,
+	0xb3, 0xeb, 0x57, 0x81, 0x4f, 0x45, 0xc1, 0xac, 0x60, 0x9c, 0x82, 0x05, 0xc7, 0x34, 0x1c, 0x3b,
+	0x53, 0xb3, 0x94, 0xed, 0x44, 0xc4, 0x82, 0xe8, 0x44, 0x24, 0x1d, 0x1b, 0xcb, 0xe1, 0x70, 0x46,
+	0xe9, 0x54, 0x82, 0xb2, 0x3d, 0xac, 0x9c, 0xab, 0x3d, 0xb4, 0x7e, 0x5e, 0x84, 0xa6, 0x1c, 0x52,
+	0xc8, 0x7e, 0x4a, 0xc1, 0xaa, 0x73, 0x48, 0x30, 0xea, 0xbd, 0x85, 0x9c, 0xf7, 0x16, 0xe7, 0xbc,
+	0xb7, 0x34, 0xff, 0xbd, 0xe5, 0x37, 0x7f, 0x6f, 0x25, 0xfd, 0xde, 0xff, 0xe4, 0xef, 0xe5, 0x26,
+	0x99, 0xd3, 0x90, 0xa9, 0x9f, 0xf2, 0x98, 0xfa, 0x5c, 0x39, 0x23, 0x15, 0x20, 0xae, 0x8c, 0x9d,
+	0x28, 0xb6, 0x67, 0x3e, 0x33, 0xc0, 0xa2, 0x2d, 0x41, 0x5c, 0xf1, 0xe9, 0x2b, 0xb6, 0x22, 0xc6,
+	0x83, 0x02, 0x54, 0x05, 0x7a, 0x83, 0x87, 0x5d, 0xfc, 0x46, 0xea, 0xe8, 0xb9, 0x37, 0x9d, 0x8a,
+	0x22, 0xbf, 0x6c, 0x4b, 0x90, 0xfd, 0xda, 0xe9, 0x45, 0x11, 0x75, 0x45, 0x41, 0x2f, 0x20, 0x94,
+	0x10, 0xff, 0xda, 0x88, 0xcd, 0xc5, 0xd5, 0x22, 0x8e, 0xe9, 0x24, 0x2c, 0x9a, 0xe3, 0xac, 0xad,
+	0xbd, 0x46, 0x37, 0xb2, 0xeb, 0x94, 0xdb, 0xb2, 0x5d, 0xa7, 0x86, 0x4f, 0xba, 0x4e, 0xb9, 0x3d,
+	0xa7, 0xeb, 0xd4, 0xed, 0xc2, 0x4e, 0x08, 0xb1, 0xeb, 0xe4, 0xc5, 0xfb, 0x9b, 0xf2, 0x67, 0xc2,
+	0x4a, 0x76, 0x23, 0x67, 0xe4, 0xd6, 0x0f, 0xa0, 0x95, 0xfa, 0x95, 0x96, 0x00, 0x54, 0x7a, 0xff,
+	0xf7, 0x64, 0x63, 0xa7, 0xdf, 0x5e, 0x20, 0x8b, 0x00, 0xbb, 0x7b, 0xfb, 0x5f, 0x0b, 0xd8, 0x20,
+	0x15, 0x28, 0x6c, 0xef, 0xb6, 0x0b, 0x48, 0x83, 0xf8, 0xed, 0xdd, 0x76, 0x91, 0xd1, 0xff, 0xff,
+	0x76, 0x7f, 0xbf, 0xdf, 0x2e, 0x29, 0x7a, 0x0e, 0x97, 0x6f, 0xdd, 0x83, 0xa6, 0x3e, 0xd9, 0x24,
+	0x6d, 0x68, 0x6e, 0x6d, 0xf7, 0x1f, 0x6f, 0xec, 0x6f, 0xde, 0xff, 0x7a, 0x6f, 0xb7, 0xd7, 0x5e,
+	0x48, 0x61, 0x36, 0x76, 0x76, 0xda, 0x06, 0x9e, 0xa1, 0x30, 0xbb, 0xed, 0xc2, 0xad, 0xcf, 0xa0,
+	0x95, 0x9a, 0x09, 0x90, 0x0b, 0xb0, 0xd4, 0x7f, 0xb8, 0xfd, 0xf8, 0xeb, 0xad, 0xde, 0xe3, 0xde,
+	0xee, 0x56, 0x6f, 0x77, 0x1f, 0x39, 0xbd, 0x00, 0x4b, 0x5f, 0x6c, 0x6c, 0xef, 0xe8, 0x48, 0xe3,
+	0xd6, 0x3b, 0xd0, 0xd4, 0x4b, 0x33, 0x64, 0xb5, 0xbf, 0xbf, 0xb5, 0xf7, 0x64, 0xbf, 0xbd, 0x20,
+	0xbe, 0x7b, 0xb6, 0xdd, 0x36, 0x6e, 0x6d, 0x43, 0x2b, 0x65, 0xfc, 0x64, 0x19, 0x5a, 0x7b, 0x4f,
+	0x7b, 0xf6, 0xce, 0xc6, 0x63, 0x64, 0x6a, 0xef, 0x19, 0x67, 0x54, 0xa2, 0xf0, 0xf6, 0xb6, 0x81,
+	0x57, 0x4a, 0x8c, 0xdd, 0x7b, 0xbc, 0xb3, 0xb1, 0xd9, 0x6b, 0x17, 0xba, 0x0f, 0xc4, 0xbf, 0x5e,
+	0xfa, 0x34, 0x3c, 0xf6, 0x86, 0x94, 0x7c, 0x0e, 0xe5, 0xfb, 0x08, 0x93, 0x39, 0x59, 0xb6, 0x33,
+	0x2f, 0xf4, 0x5b, 0x0b, 0xdd, 0x0d, 0x68, 0x1f, 0x52, 0x27, 0x8c, 0x07, 0xd4, 0x89, 0xe5, 0x79,
+	0xff, 0x0d, 0xf5, 0xfb, 0x12, 0x47, 0x32, 0x69, 0xa0, 0x93, 0x49, 0xcd, 0xd6, 0x42, 0xf7, 0x1b,
+	0x83, 0x4f, 0xb5, 0xe4, 0xf6, 0xbb, 0x50, 0xc2, 0xb2, 0x8f, 0xe4, 0xa7, 0x86, 0xce, 0x9c, 0xac,
+	0x6d, 0x2d, 0x90, 0xff, 0x85, 0xca, 0x26, 0x4f, 0x00, 0x67, 0xa4, 0x89, 0xf9, 0xfb, 0xbb, 0xbf,
+	0x2f, 0xb1, 0x5f, 0x7a, 0x24, 0x1f, 0x5f, 0x40, 0xbd, 0x2f, 0xa3, 0x22, 0x39, 0x23, 0x54, 0x76,
+	0xae, 0xe4, 0xae, 0x29, 0xb6, 0x3e, 0x81, 0xca, 0x97, 0xec, 0xc7, 0x2b, 0xa2, 0xc9, 0x31, 0xf5,
+	0x73, 0x56, 0xe7, 0x74, 0x60, 0xb2, 0x16, 0xc8, 0x26, 0xd4, 0x76, 0xc4, 0x44, 0x91, 0x68, 0xa5,
+	0x47, 0x66, 0xf0, 0xd8, 0xe9, 0xe4, 0x2d, 0xa9, 0xcb, 0xff, 0x07, 0xea, 0x9b, 0x52, 0x02, 0x67,
+	0x8a, 0x25, 0x97, 0x85, 0x87, 0xb0, 0xd8, 0x4f, 0x0d, 0x00, 0xc9, 0x8d, 0xec, 0x5b, 0x33, 0xb3,
+	0xa5, 0xce, 0x9c, 0xb9, 0x94, 0xb5, 0x40, 0x7a, 0xd0, 0xf8, 0x32, 0x19, 0xe9, 0x91, 0xab, 0x29,
+	0x61, 0x9c, 0xff, 0x18, 0x1b, 0x5a, 0x3b, 0xfa, 0x90, 0x8c, 0x5c, 0x4f, 0x0b, 0x20, 0x3b, 0x55,
+	0xeb, 0xdc, 0x98, 0xbb, 0xae, 0xa4, 0xf4, 0x10, 0x16, 0x37, 0x53, 0x93, 0x32, 0x72, 0x23, 0x2b,
+	0xaa, 0x73, 0x33, 0xd8, 0xfd, 0x87, 0x01, 0x8b, 0xc3, 0xf1, 0x2c, 0x8a, 0x69, 0x28, 0x4d, 0x69,
+	0x07, 0x1a, 0x3b, 0xc9, 0x38, 0x85, 0x5c, 0x3d, 0xcd, 0x51, 0x32, 0x7b, 0xe9, 0x5c, 0x9b, 0xb3,
+	0xaa, 0xb8, 0xdd, 0x83, 0xa6, 0xad, 0x8d, 0x47, 0xc8, 0x35, 0xbd, 0xbc, 0x39, 0x35, 0x6d, 0xe9,
+	0x5c, 0x9f, 0xb7, 0xac, 0x0e, 0x14, 0xec, 0x89, 0x41, 0x44, 0x96, 0xbd, 0xf4, 0xd4, 0xa2, 0x73,
+	0x6d, 0xce, 0xaa, 0x72, 0xa3, 0x67, 0xd0, 0x56, 0x25, 0x93, 0x14, 0xc0, 0x26, 0x34, 0x79, 0xa5,
+	0xcc, 0xd1, 0x64, 0x7e, 0x29, 0xdd, 0x99, 0x53, 0x79, 0x59, 0x0b, 0xdd, 0xa7, 0xd0, 0x12, 0xa1,
+	0x52, 0x9c, 0xda, 0x83, 0x26, 0x8f, 0x9a, 0x7b, 0x0c, 0x4d, 0xe6, 0xd5, 0x79, 0x9d, 0xb9, 0x45,
+	0xb8, 0xb5, 0x70, 0xc7, 0xe8, 0xfe, 0xd2, 0x80, 0x26, 0xeb, 0x7f, 0x34, 0xcf, 0xdf, 0x91, 0xdd,
+	0xa7, 0xee, 0x34, 0xd9, 0x9e, 0xb8, 0x73, 0x25, 0x77, 0x4d, 0xc9, 0x75, 0x1b, 0xc0, 0x56, 0x6d,
+	0x19, 0xb9, 0xa2, 0x33, 0x91, 0xe9, 0x1d, 0x3b, 0x57, 0xf3, 0x17, 0x95, 0x50, 0x9f, 0xc2, 0x62,
+	0x44, 0x23, 0x1c, 0x79, 0x4a, 0x26, 0xb7, 0xa0, 0xba, 0x19, 0xf8, 0x3e, 0x1d, 0xa6, 0xde, 0x9d,
+	0xea, 0xda, 0xf4, 0xd8, 0x90, 0x6d, 0x2a, 0xac, 0x85, 0x35, 0xe3, 0x8e, 0xd1, 0xfd, 0x53, 0x01,
+	0x96, 0x64, 0x0e, 0x96, 0x27, 0xa3, 0x37, 0xa4, 0xaa, 0xd9, 0x94, 0x37, 0xe4, 0xd5, 0xb9, 0x9d,
+	0x39, 0x75, 0x81, 0xf2, 0x7a, 0x75, 0x52, 0xda, 0xeb, 0xcf, 0x7f, 0x8c, 0xf0, 0x7a, 0xb9, 0xe1,
+	0x94, 0xd7, 0x67, 0xab, 0x9a, 0xce, 0x8d, 0xb9, 0xeb, 0x4a, 0x3d, 0x4f, 0x60, 0xd1, 0x4e, 0x15,
+	0x1c, 0xe4, 0x46, 0xd6, 0x55, 0xb2, 0x0c, 0xae, 0xce, 0x27, 0x90, 0xc7, 0xde, 0x6b, 0x7e, 0x05,
+	0xc9, 0x9f, 0x4f, 0x07, 0x15, 0x36, 0xb1, 0xfe, 0xf0, 0x9f, 0x03, 0x00, 0x9d, 0x58, 0x83, 0x51,
+	0x9e, 0x2a, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// HelloServiceClient is the client API for HelloService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HelloServiceClient interface {
+	Hello(ctx context.Context, in *HelloRequest, opts ...grpc.CallOption) (*HelloResponse, error)
+}
+
+type helloServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewHelloServiceClient(cc *grpc.ClientConn) HelloServiceClient {
+	return &helloServiceClient{cc}
+}
+
+func (c *helloServiceClient) Hello(ctx context.Context, in *HelloRequest, opts ...grpc.CallOption) (*HelloResponse, error) {
+	out := new(HelloResponse)
+	err := c.cc.Invoke(ctx, "/messages.helloService/Hello", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HelloServiceServer is the server API for HelloService service.
+type HelloServiceServer interface {
+	Hello(context.Context, *HelloRequest) (*HelloResponse, error)
+}
+
+// UnimplementedHelloServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedHelloServiceServer struct {
+}
+
+func (*UnimplementedHelloServiceServer) Hello(ctx context.Context, req *HelloRequest) (*HelloResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Hello not implemented")
+}
+
+func RegisterHelloServiceServer(s *grpc.Server, srv HelloServiceServer) {
+	s.RegisterService(&_HelloService_serviceDesc, srv)
+}
+
+func _HelloService_Hello_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HelloRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelloServiceServer).Hello(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.helloService/Hello",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelloServiceServer).Hello(ctx, req.(*HelloRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _HelloService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "messages.helloService",
+	HandlerType: (*HelloServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Hello",
+			Handler:    _HelloService_Hello_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/src/pbMessages/messages.proto",
+}
+
+// HeartbeatServiceClient is the client API for HeartbeatService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HeartbeatServiceClient interface {
+	Heartbeat(ctx context.Context, in *Ping, opts ...grpc.CallOption) (*Pong, error)
+}
+
+type heartbeatServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewHeartbeatServiceClient(cc *grpc.ClientConn) HeartbeatServiceClient {
+	return &heartbeatServiceClient{cc}
+}
+
+func (c *heartbeatServiceClient) Heartbeat(ctx context.Context, in *Ping, opts ...grpc.CallOption) (*Pong, error) {
+	out := new(Pong)
+	err := c.cc.Invoke(ctx, "/messages.heartbeatService/Heartbeat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HeartbeatServiceServer is the server API for HeartbeatService service.
+type HeartbeatServiceServer interface {
+	Heartbeat(context.Context, *Ping) (*Pong, error)
+}
+
+// UnimplementedHeartbeatServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedHeartbeatServiceServer struct {
+}
+
+func (*UnimplementedHeartbeatServiceServer) Heartbeat(ctx context.Context, req *Ping) (*Pong, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+
+func RegisterHeartbeatServiceServer(s *grpc.Server, srv HeartbeatServiceServer) {
+	s.RegisterService(&_HeartbeatService_serviceDesc, srv)
+}
+
+func _HeartbeatService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Ping)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeartbeatServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.heartbeatService/Heartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeartbeatServiceServer).Heartbeat(ctx, req.(*Ping))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _HeartbeatService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "messages.heartbeatService",
+	HandlerType: (*HeartbeatServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Heartbeat",
+			Handler:    _HeartbeatService_Heartbeat_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/src/pbMessages/messages.proto",
+}
+
+// WorkServiceClient is the client API for WorkService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WorkServiceClient interface {
+	Work(ctx context.Context, in *WorkRequest, opts ...grpc.CallOption) (*WorkResponse, error)
+	Cancel(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*WorkResponse, error)
+}
+
+type workServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWorkServiceClient(cc *grpc.ClientConn) WorkServiceClient {
+	return &workServiceClient{cc}
+}
+
+func (c *workServiceClient) Work(ctx context.Context, in *WorkRequest, opts ...grpc.CallOption) (*WorkResponse, error) {
+	out := new(WorkResponse)
+	err := c.cc.Invoke(ctx, "/messages.workService/Work", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workServiceClient) Cancel(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*WorkResponse, error) {
+	out := new(WorkResponse)
+	err := c.cc.Invoke(ctx, "/messages.workService/Cancel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorkServiceServer is the server API for WorkService service.
+type WorkServiceServer interface {
+	Work(context.Context, *WorkRequest) (*WorkResponse, error)
+	Cancel(context.Context, *CancelJobRequest) (*WorkResponse, error)
+}
+
+// UnimplementedWorkServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedWorkServiceServer struct {
+}
+
+func (*UnimplementedWorkServiceServer) Work(ctx context.Context, req *WorkRequest) (*WorkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Work not implemented")
+}
+func (*UnimplementedWorkServiceServer) Cancel(ctx context.Context, req *CancelJobRequest) (*WorkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
+
+func RegisterWorkServiceServer(s *grpc.Server, srv WorkServiceServer) {
+	s.RegisterService(&_WorkService_serviceDesc, srv)
+}
+
+func _WorkService_Work_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkServiceServer).Work(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.workService/Work",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkServiceServer).Work(ctx, req.(*WorkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkService_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkServiceServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.workService/Cancel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkServiceServer).Cancel(ctx, req.(*CancelJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _WorkService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "messages.workService",
+	HandlerType: (*WorkServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Work",
+			Handler:    _WorkService_Work_Handler,
+		},
+		{
+			MethodName: "Cancel",
+			Handler:    _WorkService_Cancel_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/src/pbMessages/messages.proto",
+}
+
+// JobServiceClient is the client API for JobService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type JobServiceClient interface {
+	SubmitJob(ctx context.Context, in *SubmitJobRequest, opts ...grpc.CallOption) (*SubmitJobResponse, error)
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*JobInfo, error)
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*JobInfo, error)
+	SubmitWorkflow(ctx context.Context, in *SubmitWorkflowRequest, opts ...grpc.CallOption) (*WorkflowInfo, error)
+	GetWorkflow(ctx context.Context, in *GetWorkflowRequest, opts ...grpc.CallOption) (*WorkflowInfo, error)
+	ListWorkflows(ctx context.Context, in *ListWorkflowsRequest, opts ...grpc.CallOption) (*ListWorkflowsResponse, error)
+	CancelWorkflow(ctx context.Context, in *CancelWorkflowRequest, opts ...grpc.CallOption) (*WorkflowInfo, error)
+}
+
+type jobServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewJobServiceClient(cc *grpc.ClientConn) JobServiceClient {
+	return &jobServiceClient{cc}
+}
+
+func (c *jobServiceClient) SubmitJob(ctx context.Context, in *SubmitJobRequest, opts ...grpc.CallOption) (*SubmitJobResponse, error) {
+	out := new(SubmitJobResponse)
+	err := c.cc.Invoke(ctx, "/messages.jobService/SubmitJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*JobInfo, error) {
+	out := new(JobInfo)
+	err := c.cc.Invoke(ctx, "/messages.jobService/GetJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, "/messages.jobService/ListJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*JobInfo, error) {
+	out := new(JobInfo)
+	err := c.cc.Invoke(ctx, "/messages.jobService/CancelJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) SubmitWorkflow(ctx context.Context, in *SubmitWorkflowRequest, opts ...grpc.CallOption) (*WorkflowInfo, error) {
+	out := new(WorkflowInfo)
+	err := c.cc.Invoke(ctx, "/messages.jobService/SubmitWorkflow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) GetWorkflow(ctx context.Context, in *GetWorkflowRequest, opts ...grpc.CallOption) (*WorkflowInfo, error) {
+	out := new(WorkflowInfo)
+	err := c.cc.Invoke(ctx, "/messages.jobService/GetWorkflow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ListWorkflows(ctx context.Context, in *ListWorkflowsRequest, opts ...grpc.CallOption) (*ListWorkflowsResponse, error) {
+	out := new(ListWorkflowsResponse)
+	err := c.cc.Invoke(ctx, "/messages.jobService/ListWorkflows", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) CancelWorkflow(ctx context.Context, in *CancelWorkflowRequest, opts ...grpc.CallOption) (*WorkflowInfo, error) {
+	out := new(WorkflowInfo)
+	err := c.cc.Invoke(ctx, "/messages.jobService/CancelWorkflow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JobServiceServer is the server API for JobService service.
+type JobServiceServer interface {
+	SubmitJob(context.Context, *SubmitJobRequest) (*SubmitJobResponse, error)
+	GetJob(context.Context, *GetJobRequest) (*JobInfo, error)
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	CancelJob(context.Context, *CancelJobRequest) (*JobInfo, error)
+	SubmitWorkflow(context.Context, *SubmitWorkflowRequest) (*WorkflowInfo, error)
+	GetWorkflow(context.Context, *GetWorkflowRequest) (*WorkflowInfo, error)
+	ListWorkflows(context.Context, *ListWorkflowsRequest) (*ListWorkflowsResponse, error)
+	CancelWorkflow(context.Context, *CancelWorkflowRequest) (*WorkflowInfo, error)
+}
+
+// UnimplementedJobServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedJobServiceServer struct {
+}
+
+func (*UnimplementedJobServiceServer) SubmitJob(ctx context.Context, req *SubmitJobRequest) (*SubmitJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitJob not implemented")
+}
+func (*UnimplementedJobServiceServer) GetJob(ctx context.Context, req *GetJobRequest) (*JobInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (*UnimplementedJobServiceServer) ListJobs(ctx context.Context, req *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (*UnimplementedJobServiceServer) CancelJob(ctx context.Context, req *CancelJobRequest) (*JobInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (*UnimplementedJobServiceServer) SubmitWorkflow(ctx context.Context, req *SubmitWorkflowRequest) (*WorkflowInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitWorkflow not implemented")
+}
+func (*UnimplementedJobServiceServer) GetWorkflow(ctx context.Context, req *GetWorkflowRequest) (*WorkflowInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflow not implemented")
+}
+func (*UnimplementedJobServiceServer) ListWorkflows(ctx context.Context, req *ListWorkflowsRequest) (*ListWorkflowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkflows not implemented")
+}
+func (*UnimplementedJobServiceServer) CancelWorkflow(ctx context.Context, req *CancelWorkflowRequest) (*WorkflowInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelWorkflow not implemented")
+}
+
+func RegisterJobServiceServer(s *grpc.Server, srv JobServiceServer) {
+	s.RegisterService(&_JobService_serviceDesc, srv)
+}
+
+func _JobService_SubmitJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).SubmitJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.jobService/SubmitJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).SubmitJob(ctx, req.(*SubmitJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.jobService/GetJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.jobService/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.jobService/CancelJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).CancelJob(ctx, req.(*CancelJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_SubmitWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).SubmitWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.jobService/SubmitWorkflow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).SubmitWorkflow(ctx, req.(*SubmitWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_GetWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).GetWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.jobService/GetWorkflow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).GetWorkflow(ctx, req.(*GetWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_ListWorkflows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkflowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ListWorkflows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.jobService/ListWorkflows",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ListWorkflows(ctx, req.(*ListWorkflowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_CancelWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).CancelWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.jobService/CancelWorkflow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).CancelWorkflow(ctx, req.(*CancelWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _JobService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "messages.jobService",
+	HandlerType: (*JobServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitJob",
+			Handler:    _JobService_SubmitJob_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _JobService_GetJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _JobService_ListJobs_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _JobService_CancelJob_Handler,
+		},
+		{
+			MethodName: "SubmitWorkflow",
+			Handler:    _JobService_SubmitWorkflow_Handler,
+		},
+		{
+			MethodName: "GetWorkflow",
+			Handler:    _JobService_GetWorkflow_Handler,
+		},
+		{
+			MethodName: "ListWorkflows",
+			Handler:    _JobService_ListWorkflows_Handler,
+		},
+		{
+			MethodName: "CancelWorkflow",
+			Handler:    _JobService_CancelWorkflow_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/src/pbMessages/messages.proto",
+}
+
+// ClusterServiceClient is the client API for ClusterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ClusterServiceClient interface {
+	ListWorkers(ctx context.Context, in *ListWorkersRequest, opts ...grpc.CallOption) (*ListWorkersResponse, error)
+	RemoveWorker(ctx context.Context, in *RemoveWorkerRequest, opts ...grpc.CallOption) (*RemoveWorkerResponse, error)
+	ListTenants(ctx context.Context, in *ListTenantsRequest, opts ...grpc.CallOption) (*ListTenantsResponse, error)
+}
+
+type clusterServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewClusterServiceClient(cc *grpc.ClientConn) ClusterServiceClient {
+	return &clusterServiceClient{cc}
+}
+
+func (c *clusterServiceClient) ListWorkers(ctx context.Context, in *ListWorkersRequest, opts ...grpc.CallOption) (*ListWorkersResponse, error) {
+	out := new(ListWorkersResponse)
+	err := c.cc.Invoke(ctx, "/messages.clusterService/ListWorkers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) RemoveWorker(ctx context.Context, in *RemoveWorkerRequest, opts ...grpc.CallOption) (*RemoveWorkerResponse, error) {
+	out := new(RemoveWorkerResponse)
+	err := c.cc.Invoke(ctx, "/messages.clusterService/RemoveWorker", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) ListTenants(ctx context.Context, in *ListTenantsRequest, opts ...grpc.CallOption) (*ListTenantsResponse, error) {
+	out := new(ListTenantsResponse)
+	err := c.cc.Invoke(ctx, "/messages.clusterService/ListTenants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClusterServiceServer is the server API for ClusterService service.
+type ClusterServiceServer interface {
+	ListWorkers(context.Context, *ListWorkersRequest) (*ListWorkersResponse, error)
+	RemoveWorker(context.Context, *RemoveWorkerRequest) (*RemoveWorkerResponse, error)
+	ListTenants(context.Context, *ListTenantsRequest) (*ListTenantsResponse, error)
+}
+
+// UnimplementedClusterServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedClusterServiceServer struct {
+}
+
+func (*UnimplementedClusterServiceServer) ListWorkers(ctx context.Context, req *ListWorkersRequest) (*ListWorkersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkers not implemented")
+}
+func (*UnimplementedClusterServiceServer) RemoveWorker(ctx context.Context, req *RemoveWorkerRequest) (*RemoveWorkerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWorker not implemented")
+}
+func (*UnimplementedClusterServiceServer) ListTenants(ctx context.Context, req *ListTenantsRequest) (*ListTenantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTenants not implemented")
+}
+
+func RegisterClusterServiceServer(s *grpc.Server, srv ClusterServiceServer) {
+	s.RegisterService(&_ClusterService_serviceDesc, srv)
+}
+
+func _ClusterService_ListWorkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).ListWorkers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.clusterService/ListWorkers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).ListWorkers(ctx, req.(*ListWorkersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_RemoveWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).RemoveWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.clusterService/RemoveWorker",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).RemoveWorker(ctx, req.(*RemoveWorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_ListTenants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTenantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).ListTenants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.clusterService/ListTenants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).ListTenants(ctx, req.(*ListTenantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ClusterService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "messages.clusterService",
+	HandlerType: (*ClusterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListWorkers",
+			Handler:    _ClusterService_ListWorkers_Handler,
+		},
+		{
+			MethodName: "RemoveWorker",
+			Handler:    _ClusterService_RemoveWorker_Handler,
+		},
+		{
+			MethodName: "ListTenants",
+			Handler:    _ClusterService_ListTenants_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/src/pbMessages/messages.proto",
+}
+
+// JobStatusServiceClient is the client API for JobStatusService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type JobStatusServiceClient interface {
+	ReportStatus(ctx context.Context, in *JobStatusReport, opts ...grpc.CallOption) (*JobStatusAck, error)
+}
+
+type jobStatusServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewJobStatusServiceClient(cc *grpc.ClientConn) JobStatusServiceClient {
+	return &jobStatusServiceClient{cc}
+}
+
+func (c *jobStatusServiceClient) ReportStatus(ctx context.Context, in *JobStatusReport, opts ...grpc.CallOption) (*JobStatusAck, error) {
+	out := new(JobStatusAck)
+	err := c.cc.Invoke(ctx, "/messages.jobStatusService/ReportStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JobStatusServiceServer is the server API for JobStatusService service.
+type JobStatusServiceServer interface {
+	ReportStatus(context.Context, *JobStatusReport) (*JobStatusAck, error)
+}
+
+// UnimplementedJobStatusServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedJobStatusServiceServer struct {
+}
+
+func (*UnimplementedJobStatusServiceServer) ReportStatus(ctx context.Context, req *JobStatusReport) (*JobStatusAck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportStatus not implemented")
+}
+
+func RegisterJobStatusServiceServer(s *grpc.Server, srv JobStatusServiceServer) {
+	s.RegisterService(&_JobStatusService_serviceDesc, srv)
+}
+
+func _JobStatusService_ReportStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobStatusReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobStatusServiceServer).ReportStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.jobStatusService/ReportStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobStatusServiceServer).ReportStatus(ctx, req.(*JobStatusReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _JobStatusService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "messages.jobStatusService",
+	HandlerType: (*JobStatusServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportStatus",
+			Handler:    _JobStatusService_ReportStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/src/pbMessages/messages.proto",
 }
 
-func init() {
-	proto.RegisterFile("internal/src/pbMessages/messages.proto", fileDescriptor_d3b30b43b8942386)
+// OutputServiceClient is the client API for OutputService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OutputServiceClient interface {
+	StreamOutput(ctx context.Context, in *RequestStdOut, opts ...grpc.CallOption) (OutputService_StreamOutputClient, error)
 }
 
-var fileDescriptor_d3b30b43b8942386 = []byte{
-	// 348 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0xcd, 0x4b, 0x3b, 0x31,
-	0x10, 0xed, 0xf7, 0xef, 0xd7, 0x71, 0x5b, 0x4a, 0xd0, 0xba, 0xec, 0xa9, 0x04, 0x94, 0x7a, 0xb0,
-	0x85, 0x8a, 0x08, 0xc5, 0x8b, 0x22, 0x52, 0x45, 0x11, 0xb6, 0x07, 0xc1, 0x5b, 0xb6, 0x8d, 0xed,
-	0xf6, 0x23, 0x49, 0x93, 0x6c, 0xfd, 0xf7, 0x65, 0xb3, 0x89, 0x2d, 0xea, 0x7a, 0x7b, 0x6f, 0x26,
-	0xf3, 0xde, 0x24, 0x2f, 0x70, 0x1a, 0x33, 0x4d, 0x25, 0x23, 0xab, 0xbe, 0x92, 0x93, 0xbe, 0x88,
-	0x9e, 0xa9, 0x52, 0x64, 0x46, 0x55, 0x7f, 0x6d, 0x41, 0x4f, 0x48, 0xae, 0x39, 0xfa, 0xef, 0x38,
-	0x7e, 0x02, 0x6f, 0x4e, 0x57, 0x2b, 0x1e, 0xd2, 0x4d, 0x42, 0x95, 0x46, 0x3e, 0xfc, 0xdb, 0x52,
-	0xa9, 0x62, 0xce, 0xfc, 0x62, 0xa7, 0xd8, 0xad, 0x86, 0x8e, 0xa2, 0x26, 0x94, 0x62, 0xe1, 0x97,
-	0x3a, 0xc5, 0x6e, 0x3d, 0x2c, 0xc5, 0x02, 0x21, 0xa8, 0xbc, 0x6f, 0xa6, 0xcc, 0x2f, 0x9b, 0x8a,
-	0xc1, 0xf8, 0x0c, 0x1a, 0x56, 0x4d, 0x09, 0xce, 0x14, 0xcd, 0x97, 0xc3, 0x01, 0x54, 0x44, 0xcc,
-	0x66, 0xa9, 0x0c, 0x23, 0x6b, 0x6a, 0xda, 0xf5, 0xd0, 0x60, 0xd3, 0xe3, 0x39, 0xbd, 0x4b, 0x38,
-	0xf8, 0xe0, 0x72, 0xe9, 0xf6, 0x3d, 0x84, 0xea, 0x82, 0x47, 0x0f, 0x77, 0x56, 0x3e, 0x23, 0xa8,
-	0x05, 0xe5, 0x05, 0x8f, 0xcc, 0xb2, 0x5e, 0x98, 0x42, 0x7c, 0x0d, 0x5e, 0x36, 0x66, 0x17, 0xfb,
-	0x7d, 0xae, 0x0d, 0x35, 0x9e, 0x68, 0x91, 0x68, 0x7b, 0x4f, 0xcb, 0xf0, 0x09, 0x34, 0x64, 0x66,
-	0x38, 0xd6, 0xd3, 0x97, 0x24, 0xc7, 0x16, 0x0f, 0xa1, 0x29, 0xad, 0xc1, 0x5f, 0xe7, 0xd2, 0x7b,
-	0x4d, 0x89, 0x26, 0xd6, 0xc4, 0xe0, 0xc1, 0xa3, 0x0d, 0x62, 0x4c, 0xe5, 0x36, 0x9e, 0x50, 0x34,
-	0x84, 0xea, 0x28, 0xe5, 0xa8, 0xdd, 0xfb, 0x0a, 0x6f, 0x3f, 0xa9, 0xe0, 0xf8, 0x47, 0x3d, 0x73,
-	0xc6, 0x85, 0xc1, 0x0d, 0xb4, 0xe6, 0x94, 0x48, 0x1d, 0x51, 0xa2, 0x9d, 0xde, 0x39, 0xd4, 0x47,
-	0xae, 0x86, 0x9a, 0xbb, 0xd9, 0x34, 0x84, 0x60, 0x9f, 0x73, 0x36, 0xc3, 0x85, 0xc1, 0x7d, 0xf6,
-	0xcc, 0x6e, 0xfa, 0x0a, 0x2a, 0xaf, 0x5c, 0x2e, 0xd1, 0xd1, 0xee, 0xe0, 0x5e, 0x0a, 0x41, 0xfb,
-	0x7b, 0xd9, 0xad, 0x72, 0xeb, 0xbd, 0xc1, 0xee, 0x1b, 0x46, 0x35, 0xf3, 0xfd, 0x2e, 0x3e, 0x03,
-	0x00, 0x00, 0xff, 0xff, 0x0d, 0x9b, 0x23, 0xf7, 0xa8, 0x02, 0x00, 0x00,
+type outputServiceClient struct {
+	cc *grpc.ClientConn
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func NewOutputServiceClient(cc *grpc.ClientConn) OutputServiceClient {
+	return &outputServiceClient{cc}
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (c *outputServiceClient) StreamOutput(ctx context.Context, in *RequestStdOut, opts ...grpc.CallOption) (OutputService_StreamOutputClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_OutputService_serviceDesc.Streams[0], "/messages.outputService/StreamOutput", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &outputServiceStreamOutputClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
 
-// HelloServiceClient is the client API for HelloService service.
+type OutputService_StreamOutputClient interface {
+	Recv() (*ResponseStdOut, error)
+	grpc.ClientStream
+}
+
+type outputServiceStreamOutputClient struct {
+	grpc.ClientStream
+}
+
+func (x *outputServiceStreamOutputClient) Recv() (*ResponseStdOut, error) {
+	m := new(ResponseStdOut)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// OutputServiceServer is the server API for OutputService service.
+type OutputServiceServer interface {
+	StreamOutput(*RequestStdOut, OutputService_StreamOutputServer) error
+}
+
+// UnimplementedOutputServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedOutputServiceServer struct {
+}
+
+func (*UnimplementedOutputServiceServer) StreamOutput(req *RequestStdOut, srv OutputService_StreamOutputServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamOutput not implemented")
+}
+
+func RegisterOutputServiceServer(s *grpc.Server, srv OutputServiceServer) {
+	s.RegisterService(&_OutputService_serviceDesc, srv)
+}
+
+func _OutputService_StreamOutput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RequestStdOut)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OutputServiceServer).StreamOutput(m, &outputServiceStreamOutputServer{stream})
+}
+
+type OutputService_StreamOutputServer interface {
+	Send(*ResponseStdOut) error
+	grpc.ServerStream
+}
+
+type outputServiceStreamOutputServer struct {
+	grpc.ServerStream
+}
+
+func (x *outputServiceStreamOutputServer) Send(m *ResponseStdOut) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _OutputService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "messages.outputService",
+	HandlerType: (*OutputServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamOutput",
+			Handler:       _OutputService_StreamOutput_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/src/pbMessages/messages.proto",
+}
+
+// LeaseServiceClient is the client API for LeaseService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type HelloServiceClient interface {
-	Hello(ctx context.Context, in *HelloRequest, opts ...grpc.CallOption) (*HelloResponse, error)
+type LeaseServiceClient interface {
+	LeaseWork(ctx context.Context, in *LeaseWorkRequest, opts ...grpc.CallOption) (*LeaseWorkResponse, error)
+	RenewLease(ctx context.Context, in *RenewLeaseRequest, opts ...grpc.CallOption) (*RenewLeaseResponse, error)
 }
 
-type helloServiceClient struct {
+type leaseServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewHelloServiceClient(cc *grpc.ClientConn) HelloServiceClient {
-	return &helloServiceClient{cc}
+func NewLeaseServiceClient(cc *grpc.ClientConn) LeaseServiceClient {
+	return &leaseServiceClient{cc}
 }
 
-func (c *helloServiceClient) Hello(ctx context.Context, in *HelloRequest, opts ...grpc.CallOption) (*HelloResponse, error) {
-	out := new(HelloResponse)
-	err := c.cc.Invoke(ctx, "/messages.helloService/Hello", in, out, opts...)
+func (c *leaseServiceClient) LeaseWork(ctx context.Context, in *LeaseWorkRequest, opts ...grpc.CallOption) (*LeaseWorkResponse, error) {
+	out := new(LeaseWorkResponse)
+	err := c.cc.Invoke(ctx, "/messages.leaseService/LeaseWork", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// HelloServiceServer is the server API for HelloService service.
-type HelloServiceServer interface {
-	Hello(context.Context, *HelloRequest) (*HelloResponse, error)
+func (c *leaseServiceClient) RenewLease(ctx context.Context, in *RenewLeaseRequest, opts ...grpc.CallOption) (*RenewLeaseResponse, error) {
+	out := new(RenewLeaseResponse)
+	err := c.cc.Invoke(ctx, "/messages.leaseService/RenewLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedHelloServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedHelloServiceServer struct {
+// LeaseServiceServer is the server API for LeaseService service.
+type LeaseServiceServer interface {
+	LeaseWork(context.Context, *LeaseWorkRequest) (*LeaseWorkResponse, error)
+	RenewLease(context.Context, *RenewLeaseRequest) (*RenewLeaseResponse, error)
 }
 
-func (*UnimplementedHelloServiceServer) Hello(ctx context.Context, req *HelloRequest) (*HelloResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Hello not implemented")
+// UnimplementedLeaseServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedLeaseServiceServer struct {
 }
 
-func RegisterHelloServiceServer(s *grpc.Server, srv HelloServiceServer) {
-	s.RegisterService(&_HelloService_serviceDesc, srv)
+func (*UnimplementedLeaseServiceServer) LeaseWork(ctx context.Context, req *LeaseWorkRequest) (*LeaseWorkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaseWork not implemented")
+}
+func (*UnimplementedLeaseServiceServer) RenewLease(ctx context.Context, req *RenewLeaseRequest) (*RenewLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewLease not implemented")
 }
 
-func _HelloService_Hello_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HelloRequest)
+func RegisterLeaseServiceServer(s *grpc.Server, srv LeaseServiceServer) {
+	s.RegisterService(&_LeaseService_serviceDesc, srv)
+}
+
+func _LeaseService_LeaseWork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseWorkRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(HelloServiceServer).Hello(ctx, in)
+		return srv.(LeaseServiceServer).LeaseWork(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/messages.helloService/Hello",
+		FullMethod: "/messages.leaseService/LeaseWork",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HelloServiceServer).Hello(ctx, req.(*HelloRequest))
+		return srv.(LeaseServiceServer).LeaseWork(ctx, req.(*LeaseWorkRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _HelloService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "messages.helloService",
-	HandlerType: (*HelloServiceServer)(nil),
+func _LeaseService_RenewLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaseServiceServer).RenewLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.leaseService/RenewLease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaseServiceServer).RenewLease(ctx, req.(*RenewLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _LeaseService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "messages.leaseService",
+	HandlerType: (*LeaseServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "Hello",
-			Handler:    _HelloService_Hello_Handler,
+			MethodName: "LeaseWork",
+			Handler:    _LeaseService_LeaseWork_Handler,
+		},
+		{
+			MethodName: "RenewLease",
+			Handler:    _LeaseService_RenewLease_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/src/pbMessages/messages.proto",
 }
 
-// HeartbeatServiceClient is the client API for HeartbeatService service.
+// SessionServiceClient is the client API for SessionService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type HeartbeatServiceClient interface {
-	Heartbeat(ctx context.Context, in *Ping, opts ...grpc.CallOption) (*Pong, error)
+type SessionServiceClient interface {
+	Connect(ctx context.Context, opts ...grpc.CallOption) (SessionService_ConnectClient, error)
 }
 
-type heartbeatServiceClient struct {
+type sessionServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewHeartbeatServiceClient(cc *grpc.ClientConn) HeartbeatServiceClient {
-	return &heartbeatServiceClient{cc}
+func NewSessionServiceClient(cc *grpc.ClientConn) SessionServiceClient {
+	return &sessionServiceClient{cc}
 }
 
-func (c *heartbeatServiceClient) Heartbeat(ctx context.Context, in *Ping, opts ...grpc.CallOption) (*Pong, error) {
-	out := new(Pong)
-	err := c.cc.Invoke(ctx, "/messages.heartbeatService/Heartbeat", in, out, opts...)
+func (c *sessionServiceClient) Connect(ctx context.Context, opts ...grpc.CallOption) (SessionService_ConnectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SessionService_serviceDesc.Streams[0], "/messages.sessionService/Connect", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &sessionServiceConnectClient{stream}
+	return x, nil
 }
 
-// HeartbeatServiceServer is the server API for HeartbeatService service.
-type HeartbeatServiceServer interface {
-	Heartbeat(context.Context, *Ping) (*Pong, error)
+type SessionService_ConnectClient interface {
+	Send(*WorkerMessage) error
+	Recv() (*CommanderMessage, error)
+	grpc.ClientStream
 }
 
-// UnimplementedHeartbeatServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedHeartbeatServiceServer struct {
+type sessionServiceConnectClient struct {
+	grpc.ClientStream
 }
 
-func (*UnimplementedHeartbeatServiceServer) Heartbeat(ctx context.Context, req *Ping) (*Pong, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+func (x *sessionServiceConnectClient) Send(m *WorkerMessage) error {
+	return x.ClientStream.SendMsg(m)
 }
 
-func RegisterHeartbeatServiceServer(s *grpc.Server, srv HeartbeatServiceServer) {
-	s.RegisterService(&_HeartbeatService_serviceDesc, srv)
+func (x *sessionServiceConnectClient) Recv() (*CommanderMessage, error) {
+	m := new(CommanderMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func _HeartbeatService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Ping)
-	if err := dec(in); err != nil {
+// SessionServiceServer is the server API for SessionService service.
+type SessionServiceServer interface {
+	Connect(SessionService_ConnectServer) error
+}
+
+// UnimplementedSessionServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedSessionServiceServer struct {
+}
+
+func (*UnimplementedSessionServiceServer) Connect(srv SessionService_ConnectServer) error {
+	return status.Errorf(codes.Unimplemented, "method Connect not implemented")
+}
+
+func RegisterSessionServiceServer(s *grpc.Server, srv SessionServiceServer) {
+	s.RegisterService(&_SessionService_serviceDesc, srv)
+}
+
+func _SessionService_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SessionServiceServer).Connect(&sessionServiceConnectServer{stream})
+}
+
+type SessionService_ConnectServer interface {
+	Send(*CommanderMessage) error
+	Recv() (*WorkerMessage, error)
+	grpc.ServerStream
+}
+
+type sessionServiceConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *sessionServiceConnectServer) Send(m *CommanderMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *sessionServiceConnectServer) Recv() (*WorkerMessage, error) {
+	m := new(WorkerMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(HeartbeatServiceServer).Heartbeat(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/messages.heartbeatService/Heartbeat",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeartbeatServiceServer).Heartbeat(ctx, req.(*Ping))
-	}
-	return interceptor(ctx, in, info, handler)
+	return m, nil
 }
 
-var _HeartbeatService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "messages.heartbeatService",
-	HandlerType: (*HeartbeatServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
+var _SessionService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "messages.sessionService",
+	HandlerType: (*SessionServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
 		{
-			MethodName: "Heartbeat",
-			Handler:    _HeartbeatService_Heartbeat_Handler,
+			StreamName:    "Connect",
+			Handler:       _SessionService_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/src/pbMessages/messages.proto",
 }
 
-// WorkServiceClient is the client API for WorkService service.
+// ScheduleServiceClient is the client API for ScheduleService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type WorkServiceClient interface {
-	Work(ctx context.Context, in *WorkRequest, opts ...grpc.CallOption) (*WorkResponse, error)
+type ScheduleServiceClient interface {
+	CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*ScheduleInfo, error)
+	GetSchedule(ctx context.Context, in *GetScheduleRequest, opts ...grpc.CallOption) (*ScheduleInfo, error)
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	RemoveSchedule(ctx context.Context, in *RemoveScheduleRequest, opts ...grpc.CallOption) (*RemoveScheduleResponse, error)
 }
 
-type workServiceClient struct {
+type scheduleServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewWorkServiceClient(cc *grpc.ClientConn) WorkServiceClient {
-	return &workServiceClient{cc}
+func NewScheduleServiceClient(cc *grpc.ClientConn) ScheduleServiceClient {
+	return &scheduleServiceClient{cc}
 }
 
-func (c *workServiceClient) Work(ctx context.Context, in *WorkRequest, opts ...grpc.CallOption) (*WorkResponse, error) {
-	out := new(WorkResponse)
-	err := c.cc.Invoke(ctx, "/messages.workService/Work", in, out, opts...)
+func (c *scheduleServiceClient) CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*ScheduleInfo, error) {
+	out := new(ScheduleInfo)
+	err := c.cc.Invoke(ctx, "/messages.scheduleService/CreateSchedule", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// WorkServiceServer is the server API for WorkService service.
-type WorkServiceServer interface {
-	Work(context.Context, *WorkRequest) (*WorkResponse, error)
+func (c *scheduleServiceClient) GetSchedule(ctx context.Context, in *GetScheduleRequest, opts ...grpc.CallOption) (*ScheduleInfo, error) {
+	out := new(ScheduleInfo)
+	err := c.cc.Invoke(ctx, "/messages.scheduleService/GetSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedWorkServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedWorkServiceServer struct {
+func (c *scheduleServiceClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, "/messages.scheduleService/ListSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (*UnimplementedWorkServiceServer) Work(ctx context.Context, req *WorkRequest) (*WorkResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Work not implemented")
+func (c *scheduleServiceClient) RemoveSchedule(ctx context.Context, in *RemoveScheduleRequest, opts ...grpc.CallOption) (*RemoveScheduleResponse, error) {
+	out := new(RemoveScheduleResponse)
+	err := c.cc.Invoke(ctx, "/messages.scheduleService/RemoveSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func RegisterWorkServiceServer(s *grpc.Server, srv WorkServiceServer) {
-	s.RegisterService(&_WorkService_serviceDesc, srv)
+// ScheduleServiceServer is the server API for ScheduleService service.
+type ScheduleServiceServer interface {
+	CreateSchedule(context.Context, *CreateScheduleRequest) (*ScheduleInfo, error)
+	GetSchedule(context.Context, *GetScheduleRequest) (*ScheduleInfo, error)
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	RemoveSchedule(context.Context, *RemoveScheduleRequest) (*RemoveScheduleResponse, error)
 }
 
-func _WorkService_Work_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WorkRequest)
+// UnimplementedScheduleServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedScheduleServiceServer struct {
+}
+
+func (*UnimplementedScheduleServiceServer) CreateSchedule(ctx context.Context, req *CreateScheduleRequest) (*ScheduleInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSchedule not implemented")
+}
+func (*UnimplementedScheduleServiceServer) GetSchedule(ctx context.Context, req *GetScheduleRequest) (*ScheduleInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedule not implemented")
+}
+func (*UnimplementedScheduleServiceServer) ListSchedules(ctx context.Context, req *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (*UnimplementedScheduleServiceServer) RemoveSchedule(ctx context.Context, req *RemoveScheduleRequest) (*RemoveScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSchedule not implemented")
+}
+
+func RegisterScheduleServiceServer(s *grpc.Server, srv ScheduleServiceServer) {
+	s.RegisterService(&_ScheduleService_serviceDesc, srv)
+}
+
+func _ScheduleService_CreateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WorkServiceServer).Work(ctx, in)
+		return srv.(ScheduleServiceServer).CreateSchedule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/messages.workService/Work",
+		FullMethod: "/messages.scheduleService/CreateSchedule",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WorkServiceServer).Work(ctx, req.(*WorkRequest))
+		return srv.(ScheduleServiceServer).CreateSchedule(ctx, req.(*CreateScheduleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _WorkService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "messages.workService",
-	HandlerType: (*WorkServiceServer)(nil),
+func _ScheduleService_GetSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.scheduleService/GetSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetSchedule(ctx, req.(*GetScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.scheduleService/ListSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_RemoveSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).RemoveSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/messages.scheduleService/RemoveSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).RemoveSchedule(ctx, req.(*RemoveScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ScheduleService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "messages.scheduleService",
+	HandlerType: (*ScheduleServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "Work",
-			Handler:    _WorkService_Work_Handler,
+			MethodName: "CreateSchedule",
+			Handler:    _ScheduleService_CreateSchedule_Handler,
+		},
+		{
+			MethodName: "GetSchedule",
+			Handler:    _ScheduleService_GetSchedule_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _ScheduleService_ListSchedules_Handler,
+		},
+		{
+			MethodName: "RemoveSchedule",
+			Handler:    _ScheduleService_RemoveSchedule_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
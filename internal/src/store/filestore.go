@@ -0,0 +1,334 @@
+package store
+
+import (
+	"bufio"
+	"common"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Number of log records after which the log is folded into a new snapshot
+const snapshotEvery = 1000
+
+// KeepFinished is how long a finished job is kept for before it is left out
+// of the next snapshot
+var KeepFinished = 7 * 24 * time.Hour
+
+const (
+	snapshotFile = "snapshot.json"
+	logFile      = "log.jsonl"
+	oldLogFile   = "log.old.jsonl" // the log moved aside while a snapshot is written in the background
+)
+
+// record is one line of the append-only log, exactly one field is set
+type record struct {
+	Job      *common.Job      `json:",omitempty"`
+	Worker   *WorkerRecord    `json:",omitempty"`
+	Schedule *common.Schedule `json:",omitempty"`
+}
+
+// FileStore is an embedded on-disk Store. Every save is appended to a log
+// file which is periodically compacted into a snapshot; opening the store
+// replays the snapshot and then the log.
+type FileStore struct {
+	mtx     sync.Mutex
+	dir     string
+	log     *os.File
+	state   *State
+	records int
+
+	snapshotting   bool // a snapshot is being written in the background
+	snapshotFailed bool // the last one failed, so the old log is still needed
+	snapshots      int  // snapshots begun
+	background     sync.WaitGroup
+
+	// held while a snapshot is written, and guarding how many have been
+	// written so an older one never replaces a newer one
+	snapshotMtx sync.Mutex
+	written     int
+}
+
+// OpenFileStore opens (or creates) the store kept in dir and replays it
+func OpenFileStore(dir string) (*FileStore, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	fs := &FileStore{dir: dir, state: newState()}
+
+	err = fs.readSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %v", err)
+	}
+	// left behind if the Commander stopped while writing a snapshot
+	err = fs.replayLog(oldLogFile)
+	if err != nil {
+		return nil, fmt.Errorf("replaying old log: %v", err)
+	}
+	err = fs.replayLog(logFile)
+	if err != nil {
+		return nil, fmt.Errorf("replaying log: %v", err)
+	}
+
+	fs.log, err = os.OpenFile(filepath.Join(dir, logFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	// start from a fresh snapshot so the log only holds this run's changes
+	if fs.records > 0 {
+		err = fs.snapshot()
+		if err != nil {
+			fs.log.Close()
+			return nil, err
+		}
+	}
+	return fs, nil
+}
+
+func (fs *FileStore) readSnapshot() error {
+	f, err := os.Open(filepath.Join(fs.dir, snapshotFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var snap State
+	err = json.NewDecoder(f).Decode(&snap)
+	if err != nil {
+		return err
+	}
+	fs.state.merge(&snap)
+	return nil
+}
+
+// replayLog applies every record in the log. A record torn by a crash part
+// way through writing it is cut off the end of the log, so the next record
+// appended starts on a line of its own.
+func (fs *FileStore) replayLog(name string) error {
+	path := filepath.Join(fs.dir, name)
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	// how much of the log holds whole records
+	var good int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(line) > 0 {
+				log.Printf("Cutting an incomplete record of %d bytes off the end of %s\n", len(line), name)
+				return os.Truncate(path, good)
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var rec record
+		err = json.Unmarshal(line, &rec)
+		if err != nil {
+			return err
+		}
+		fs.apply(&rec)
+		fs.records += 1
+		good += int64(len(line))
+	}
+}
+
+func (fs *FileStore) apply(rec *record) {
+	if rec.Job != nil {
+		fs.state.applyJob(rec.Job)
+	}
+	if rec.Worker != nil {
+		fs.state.applyWorker(rec.Worker)
+	}
+	if rec.Schedule != nil {
+		fs.state.applySchedule(rec.Schedule)
+	}
+}
+
+func (fs *FileStore) append(rec *record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	fs.mtx.Lock()
+	defer fs.mtx.Unlock()
+	if fs.log == nil {
+		return fmt.Errorf("store is closed")
+	}
+
+	_, err = fs.log.Write(append(data, '\n'))
+	if err != nil {
+		return err
+	}
+	err = fs.log.Sync()
+	if err != nil {
+		return err
+	}
+
+	// keep our own copy, the caller is free to carry on modifying theirs
+	var saved record
+	err = json.Unmarshal(data, &saved)
+	if err != nil {
+		return err
+	}
+	fs.apply(&saved)
+
+	fs.records += 1
+	if fs.records >= snapshotEvery && !fs.snapshotting {
+		if fs.snapshotFailed {
+			return fs.snapshot()
+		}
+		return fs.startSnapshot()
+	}
+	return nil
+}
+
+// startSnapshot moves the log aside and starts a new one, then writes out the
+// state up to that point in the background so saves are not held up by it.
+// fs.mtx must be held.
+func (fs *FileStore) startSnapshot() error {
+	fs.state.compact(time.Now().Add(-KeepFinished))
+	// the state is only ever added to or replaced in, never changed in
+	// place, so a shallow copy is enough to write out
+	state := fs.state.copy()
+
+	path := filepath.Join(fs.dir, logFile)
+	fs.log.Close()
+	moved := os.Rename(path, filepath.Join(fs.dir, oldLogFile))
+	var err error
+	fs.log, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if moved != nil {
+		// still appending to the same log, so fold it in straight away
+		return fs.snapshot()
+	}
+	fs.records = 0
+	fs.snapshotting = true
+	fs.snapshots += 1
+	generation := fs.snapshots
+
+	fs.background.Add(1)
+	go func() {
+		defer fs.background.Done()
+		fs.snapshotMtx.Lock()
+		var err error
+		if generation > fs.written {
+			err = fs.writeSnapshot(state, generation)
+			if err == nil {
+				err = os.Remove(filepath.Join(fs.dir, oldLogFile))
+			}
+		}
+		fs.snapshotMtx.Unlock()
+		if err != nil {
+			log.Printf("ERROR: writing snapshot: %v\n", err)
+		}
+
+		fs.mtx.Lock()
+		fs.snapshotting = false
+		fs.snapshotFailed = err != nil
+		fs.mtx.Unlock()
+	}()
+	return nil
+}
+
+// snapshot writes the current state out and empties the logs, fs.mtx must be
+// held or the store not yet shared
+func (fs *FileStore) snapshot() error {
+	fs.state.compact(time.Now().Add(-KeepFinished))
+	fs.snapshots += 1
+	fs.snapshotMtx.Lock()
+	defer fs.snapshotMtx.Unlock()
+	err := fs.writeSnapshot(fs.state, fs.snapshots)
+	if err != nil {
+		return err
+	}
+
+	// replaying the logs over the snapshot is harmless, so a crash between
+	// the rename and emptying them loses nothing
+	err = os.Remove(filepath.Join(fs.dir, oldLogFile))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	fs.snapshotFailed = false
+	err = fs.log.Truncate(0)
+	if err != nil {
+		return err
+	}
+	fs.records = 0
+	return nil
+}
+
+// writeSnapshot replaces the snapshot with state, fs.snapshotMtx must be held
+func (fs *FileStore) writeSnapshot(state *State, generation int) error {
+	path := filepath.Join(fs.dir, snapshotFile)
+	f, err := os.Create(path + ".tmp")
+	if err != nil {
+		return err
+	}
+	err = json.NewEncoder(f).Encode(state)
+	if err == nil {
+		err = f.Sync()
+	}
+	f.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(path+".tmp", path)
+	if err != nil {
+		return err
+	}
+	fs.written = generation
+	return nil
+}
+
+func (fs *FileStore) SaveJob(job *common.Job) error {
+	return fs.append(&record{Job: job})
+}
+
+func (fs *FileStore) SaveWorker(worker *WorkerRecord) error {
+	return fs.append(&record{Worker: worker})
+}
+
+func (fs *FileStore) SaveSchedule(schedule *common.Schedule) error {
+	return fs.append(&record{Schedule: schedule})
+}
+
+func (fs *FileStore) Load() (*State, error) {
+	fs.mtx.Lock()
+	defer fs.mtx.Unlock()
+	return fs.state.clone()
+}
+
+func (fs *FileStore) Close() error {
+	fs.background.Wait()
+	fs.mtx.Lock()
+	defer fs.mtx.Unlock()
+	if fs.log == nil {
+		return nil
+	}
+	err := fs.snapshot()
+	fs.log.Close()
+	fs.log = nil
+	return err
+}
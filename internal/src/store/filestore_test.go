@@ -0,0 +1,286 @@
+package store
+
+import (
+	"common"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// crash drops a store without closing it, as if the Commander had died, once
+// any snapshot being written in the background is done
+func crash(fs *FileStore) {
+	fs.background.Wait()
+	fs.log.Close()
+	fs.log = nil
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "filestore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// logLine returns the log record saving a job with the given ID
+func logLine(t *testing.T, id int32) string {
+	data, err := json.Marshal(&record{Job: &common.Job{ID: id, Command: "true"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data) + "\n"
+}
+
+func jobIDs(state *State) []int32 {
+	var ids []int32
+	for _, job := range state.Jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
+
+func TestFileStoreReopen(t *testing.T) {
+	tests := []struct {
+		name  string
+		close func(fs *FileStore)
+	}{
+		{"closed", func(fs *FileStore) { fs.Close() }},
+		{"crashed", crash},
+	}
+	for _, test := range tests {
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+
+		fs, err := OpenFileStore(dir)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		fs.SaveJob(&common.Job{ID: 1, Command: "true"})
+		fs.SaveJob(&common.Job{ID: 2, Command: "false"})
+		fs.SaveJob(&common.Job{ID: 1, Command: "true", Status: common.SUCCESS})
+		fs.SaveWorker(&WorkerRecord{Host: "a", IP: "10.0.0.1"})
+		fs.SaveWorker(&WorkerRecord{Host: "b", IP: "10.0.0.2"})
+		fs.SaveWorker(&WorkerRecord{Host: "b", Removed: true})
+		fs.SaveSchedule(&common.Schedule{ID: 1, Cron: "@daily"})
+		fs.SaveSchedule(&common.Schedule{ID: 2, Cron: "@hourly"})
+		fs.SaveSchedule(&common.Schedule{ID: 2, Removed: true})
+		test.close(fs)
+
+		fs, err = OpenFileStore(dir)
+		if err != nil {
+			t.Fatalf("%s: reopening: %v", test.name, err)
+		}
+		state, err := fs.Load()
+		fs.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if ids := jobIDs(state); !reflect.DeepEqual(ids, []int32{1, 2}) {
+			t.Errorf("%s: jobs %v, want [1 2]", test.name, ids)
+		}
+		if state.Jobs[0].Status != common.SUCCESS {
+			t.Errorf("%s: job 1 is %v, want SUCCESS", test.name, state.Jobs[0].Status)
+		}
+		if len(state.Workers) != 1 || state.Workers["a"] == nil {
+			t.Errorf("%s: workers %v, want only a", test.name, state.Workers)
+		}
+		if len(state.Schedules) != 1 {
+			t.Errorf("%s: %d schedules, want 1", test.name, len(state.Schedules))
+		}
+		// so the removed schedule's ID is not handed out again
+		if state.LastScheduleID != 2 {
+			t.Errorf("%s: last schedule ID %d, want 2", test.name, state.LastScheduleID)
+		}
+	}
+}
+
+func TestFileStoreTornLog(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(t *testing.T) string
+		want []int32
+	}{
+		{"only a torn record", func(t *testing.T) string {
+			return `{"Job":{"ID":1,"Comm`
+		}, []int32{3}},
+		{"torn after a key", func(t *testing.T) string {
+			return `{"Job"`
+		}, []int32{3}},
+		{"whole records then a torn one", func(t *testing.T) string {
+			return logLine(t, 1) + logLine(t, 2) + `{"Job":{"ID":4`
+		}, []int32{1, 2, 3}},
+		{"whole records only", func(t *testing.T) string {
+			return logLine(t, 1) + logLine(t, 2)
+		}, []int32{1, 2, 3}},
+	}
+	for _, test := range tests {
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+		err := ioutil.WriteFile(filepath.Join(dir, logFile), []byte(test.log(t)), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fs, err := OpenFileStore(dir)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		err = fs.SaveJob(&common.Job{ID: 3, Command: "true"})
+		if err != nil {
+			t.Fatalf("%s: appending: %v", test.name, err)
+		}
+		crash(fs)
+
+		// the record appended after the torn one must not be glued onto it
+		fs, err = OpenFileStore(dir)
+		if err != nil {
+			t.Fatalf("%s: reopening: %v", test.name, err)
+		}
+		state, err := fs.Load()
+		fs.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if ids := jobIDs(state); !reflect.DeepEqual(ids, test.want) {
+			t.Errorf("%s: jobs %v, want %v", test.name, ids, test.want)
+		}
+	}
+}
+
+// finishedJob returns a job which finished at the given time
+func finishedJob(id int32, workflow int32, stat common.Status, at time.Time) *common.Job {
+	return &common.Job{
+		ID:       id,
+		Command:  "true",
+		Workflow: workflow,
+		Status:   stat,
+		History:  []common.Transition{{Status: stat, Time: at}},
+	}
+}
+
+func TestStateCompact(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-2 * time.Hour)
+	cutoff := now.Add(-time.Hour)
+	fanOut := finishedJob(8, 0, common.RUNNING, old)
+	child := finishedJob(9, 0, common.SUCCESS, old)
+	child.Parent = 8
+
+	tests := []struct {
+		name string
+		jobs []*common.Job
+		want []int32
+	}{
+		{"recently finished", []*common.Job{finishedJob(1, 0, common.SUCCESS, now)}, []int32{1}},
+		{"finished long ago", []*common.Job{finishedJob(1, 0, common.SUCCESS, old), finishedJob(2, 0, common.FAILED, old)}, nil},
+		{"still going", []*common.Job{finishedJob(1, 0, common.RUNNING, old), finishedJob(2, 0, common.WAITING, old)}, []int32{1, 2}},
+		{"workflow still going", []*common.Job{finishedJob(1, 1, common.SUCCESS, old), finishedJob(2, 1, common.BLOCKED, old)}, []int32{1, 2}},
+		{"workflow finished", []*common.Job{finishedJob(1, 1, common.SUCCESS, old), finishedJob(2, 1, common.SKIPPED, old), finishedJob(3, 2, common.WAITING, now)}, []int32{3}},
+		{"fanned out from a job still going", []*common.Job{fanOut, child}, []int32{8, 9}},
+	}
+	for _, test := range tests {
+		st := newState()
+		for _, job := range test.jobs {
+			st.applyJob(job)
+		}
+		last := st.LastJobID
+		st.compact(cutoff)
+		if ids := jobIDs(st); !reflect.DeepEqual(ids, test.want) {
+			t.Errorf("%s: kept %v, want %v", test.name, ids, test.want)
+		}
+		if st.LastJobID != last {
+			t.Errorf("%s: last job ID %d, want %d", test.name, st.LastJobID, last)
+		}
+		for i, job := range st.Jobs {
+			if st.jobIndex[job.ID] != i {
+				t.Errorf("%s: job %d indexed at %d, want %d", test.name, job.ID, st.jobIndex[job.ID], i)
+			}
+		}
+	}
+}
+
+func TestFileStoreSnapshots(t *testing.T) {
+	tests := []struct {
+		name  string
+		saves int
+		close func(fs *FileStore)
+	}{
+		{"closed after a background snapshot", snapshotEvery + 10, func(fs *FileStore) { fs.Close() }},
+		{"crashed after a background snapshot", snapshotEvery + 10, crash},
+		{"closed after several", 3*snapshotEvery + 10, func(fs *FileStore) { fs.Close() }},
+		{"crashed after several", 3*snapshotEvery + 10, crash},
+	}
+	for _, test := range tests {
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+
+		fs, err := OpenFileStore(dir)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		for i := 1; i <= test.saves; i++ {
+			err = fs.SaveJob(&common.Job{ID: int32(i%100 + 1), Command: "true", ExitCode: i})
+			if err != nil {
+				t.Fatalf("%s: saving: %v", test.name, err)
+			}
+		}
+		test.close(fs)
+
+		fs, err = OpenFileStore(dir)
+		if err != nil {
+			t.Fatalf("%s: reopening: %v", test.name, err)
+		}
+		state, err := fs.Load()
+		fs.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if len(state.Jobs) != 100 {
+			t.Fatalf("%s: %d jobs, want 100", test.name, len(state.Jobs))
+		}
+		// the last save of each job wins
+		for _, job := range state.Jobs {
+			want := test.saves - (test.saves-int(job.ID)+1)%100
+			if job.ExitCode != want {
+				t.Errorf("%s: job %d has exit code %d, want %d", test.name, job.ID, job.ExitCode, want)
+			}
+		}
+		if state.LastJobID != 100 {
+			t.Errorf("%s: last job ID %d, want 100", test.name, state.LastJobID)
+		}
+	}
+}
+
+func TestFileStoreOldLog(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	// as left by a crash while a snapshot was being written
+	err := ioutil.WriteFile(filepath.Join(dir, oldLogFile), []byte(logLine(t, 1)+logLine(t, 2)), 0644)
+	if err == nil {
+		err = ioutil.WriteFile(filepath.Join(dir, logFile), []byte(logLine(t, 3)), 0644)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs, err := OpenFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := fs.Load()
+	fs.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := jobIDs(state); !reflect.DeepEqual(ids, []int32{1, 2, 3}) {
+		t.Errorf("jobs %v, want [1 2 3]", ids)
+	}
+	if _, err := os.Stat(filepath.Join(dir, oldLogFile)); !os.IsNotExist(err) {
+		t.Errorf("old log still there after opening: %v", err)
+	}
+}
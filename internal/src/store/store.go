@@ -0,0 +1,199 @@
+package store
+
+import (
+	"common"
+	"encoding/json"
+	"time"
+)
+
+// WorkerRecord is the persisted part of a worker's registry entry
+type WorkerRecord struct {
+	Host    string // the worker's ID
+	IP      string
+	Fqdn    string
+	Removed bool
+}
+
+// State is everything a Store knows about, as rebuilt from disk
+type State struct {
+	Jobs      []*common.Job // in submission order
+	Workers   map[string]*WorkerRecord
+	Schedules map[int32]*common.Schedule
+
+	// the highest IDs handed out, which outlive the jobs they were given to
+	LastJobID      int32
+	LastWorkflowID int32
+	LastScheduleID int32
+
+	jobIndex map[int32]int
+}
+
+// Store persists jobs, the worker registry and schedules so the Commander
+// can be restarted without losing them
+type Store interface {
+	// SaveJob records the current state of a job, including status changes
+	SaveJob(job *common.Job) error
+	// SaveWorker records the current state of a worker registry entry
+	SaveWorker(worker *WorkerRecord) error
+	// SaveSchedule records the current state of a schedule
+	SaveSchedule(schedule *common.Schedule) error
+	// Load returns the state replayed from everything saved so far
+	Load() (*State, error)
+	// Close flushes the store, no further saves are allowed
+	Close() error
+}
+
+func newState() *State {
+	return &State{Workers: make(map[string]*WorkerRecord), Schedules: make(map[int32]*common.Schedule), jobIndex: make(map[int32]int)}
+}
+
+func (st *State) applyJob(job *common.Job) {
+	if job.ID > st.LastJobID {
+		st.LastJobID = job.ID
+	}
+	if job.Workflow > st.LastWorkflowID {
+		st.LastWorkflowID = job.Workflow
+	}
+	i, found := st.jobIndex[job.ID]
+	if found {
+		st.Jobs[i] = job
+		return
+	}
+	st.jobIndex[job.ID] = len(st.Jobs)
+	st.Jobs = append(st.Jobs, job)
+}
+
+// compact forgets the jobs which finished before cutoff, unless another job
+// may still need them: one in the same workflow, or the job they were fanned
+// out from, which has not finished yet
+func (st *State) compact(cutoff time.Time) {
+	unfinished := make(map[int32]bool)
+	workflows := make(map[int32]bool)
+	for _, job := range st.Jobs {
+		if !job.Status.Finished() {
+			unfinished[job.ID] = true
+			workflows[job.Workflow] = true
+		}
+	}
+
+	var kept []*common.Job
+	for _, job := range st.Jobs {
+		old := job.Status.Finished() && len(job.History) > 0 && job.History[len(job.History)-1].Time.Before(cutoff)
+		if !old || (job.Workflow != 0 && workflows[job.Workflow]) || unfinished[job.Parent] {
+			kept = append(kept, job)
+		}
+	}
+	if len(kept) == len(st.Jobs) {
+		return
+	}
+	st.Jobs = kept
+	st.jobIndex = make(map[int32]int)
+	for i, job := range st.Jobs {
+		st.jobIndex[job.ID] = i
+	}
+}
+
+// copy returns a copy of st which shares the jobs, workers and schedules in
+// it but not the slice and maps holding them
+func (st *State) copy() *State {
+	cp := &State{
+		Jobs:           append([]*common.Job{}, st.Jobs...),
+		Workers:        make(map[string]*WorkerRecord),
+		Schedules:      make(map[int32]*common.Schedule),
+		LastJobID:      st.LastJobID,
+		LastWorkflowID: st.LastWorkflowID,
+		LastScheduleID: st.LastScheduleID,
+	}
+	for host, worker := range st.Workers {
+		cp.Workers[host] = worker
+	}
+	for id, schedule := range st.Schedules {
+		cp.Schedules[id] = schedule
+	}
+	return cp
+}
+
+// merge applies every job, worker and schedule in other on top of st
+func (st *State) merge(other *State) {
+	if other.LastJobID > st.LastJobID {
+		st.LastJobID = other.LastJobID
+	}
+	if other.LastWorkflowID > st.LastWorkflowID {
+		st.LastWorkflowID = other.LastWorkflowID
+	}
+	if other.LastScheduleID > st.LastScheduleID {
+		st.LastScheduleID = other.LastScheduleID
+	}
+	for _, job := range other.Jobs {
+		st.applyJob(job)
+	}
+	for _, worker := range other.Workers {
+		st.applyWorker(worker)
+	}
+	for _, schedule := range other.Schedules {
+		st.applySchedule(schedule)
+	}
+}
+
+// clone returns a deep copy of st, sharing nothing with it
+func (st *State) clone() (*State, error) {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return nil, err
+	}
+	var decoded State
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		return nil, err
+	}
+	cp := newState()
+	cp.merge(&decoded)
+	return cp, nil
+}
+
+func (st *State) applyWorker(worker *WorkerRecord) {
+	if worker.Removed {
+		delete(st.Workers, worker.Host)
+		return
+	}
+	st.Workers[worker.Host] = worker
+}
+
+func (st *State) applySchedule(schedule *common.Schedule) {
+	if schedule.ID > st.LastScheduleID {
+		st.LastScheduleID = schedule.ID
+	}
+	if schedule.Removed {
+		delete(st.Schedules, schedule.ID)
+		return
+	}
+	st.Schedules[schedule.ID] = schedule
+}
+
+// MemoryStore keeps nothing, every Commander restart starts from scratch
+type MemoryStore struct {
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (*MemoryStore) SaveJob(job *common.Job) error {
+	return nil
+}
+
+func (*MemoryStore) SaveWorker(worker *WorkerRecord) error {
+	return nil
+}
+
+func (*MemoryStore) SaveSchedule(schedule *common.Schedule) error {
+	return nil
+}
+
+func (*MemoryStore) Load() (*State, error) {
+	return newState(), nil
+}
+
+func (*MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"common"
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+// Long-lived connections to the commander, by address
+var (
+	conns    = make(map[string]*grpc.ClientConn)
+	connsMtx sync.Mutex
+)
+
+// getConn returns the connection to an address, dialling it the first time.
+// The connection is kept for the life of the worker and reconnects by itself.
+func getConn(connString string) (*grpc.ClientConn, error) {
+	connsMtx.Lock()
+	defer connsMtx.Unlock()
+	cc, found := conns[connString]
+	if found {
+		return cc, nil
+	}
+	cc, err := grpc.Dial(connString, grpc.WithInsecure(), grpc.WithKeepaliveParams(common.ClientKeepalive))
+	if err != nil {
+		return nil, err
+	}
+	conns[connString] = cc
+	return cc, nil
+}
+
+// CloseConns closes every connection, ready for shutdown
+func CloseConns() {
+	connsMtx.Lock()
+	defer connsMtx.Unlock()
+	for connString, cc := range conns {
+		cc.Close()
+		delete(conns, connString)
+	}
+}
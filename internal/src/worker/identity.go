@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// ID identifies the worker to the commander, it stays the same across
+// restarts and address changes
+var ID string
+
+// LoadID reads the worker's ID from a file, generating a new one and saving
+// it there on first start
+func LoadID(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err == nil {
+		id := strings.TrimSpace(string(data))
+		if id != "" {
+			return id, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	id, err := newUUID()
+	if err != nil {
+		return "", err
+	}
+	err = ioutil.WriteFile(path, []byte(id+"\n"), 0644)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// newUUID returns a random (version 4) UUID
+func newUUID() (string, error) {
+	var b [16]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,219 @@
+package worker
+
+import (
+	"common"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"pbMessages"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// How much of each output stream is sent with the final status report, the
+// whole of it can be read through the OutputService
+const reportOutputSize = 64 * 1024
+
+// cmdResult is everything we know about a command once it has finished
+type cmdResult struct {
+	exitCode int
+	signal   string
+	err      error // set if the command could not be run at all
+	start    time.Time
+	end      time.Time
+}
+
+// runJob executes a job sent by the commander, reporting back once it is
+// running and again when it has finished
+func runJob(jobID int32, attempt int, job *common.Job, out *jobOutput, proc *process) {
+	started := func(start time.Time) {
+		report := &pbMessages.JobStatusReport{
+			JobID:     jobID,
+			Attempt:   int32(attempt),
+			Worker:    ID,
+			Status:    int32(common.RUNNING),
+			Time:      start.UnixNano(),
+			StartTime: start.UnixNano(),
+		}
+		// not worth holding anything up for, the final report implies it
+		go reportStatus(report, 3)
+	}
+	var timer *time.Timer
+	if job.Timeout > 0 {
+		timer = time.AfterFunc(job.Timeout, func() {
+			if DebugLog {
+				fmt.Printf("Job %d timed out after %v\n", jobID, job.Timeout)
+			}
+			proc.stop(common.TIMED_OUT)
+		})
+	}
+	result := executeCmd(job, out, proc, started)
+	if timer != nil {
+		// reporting the result may take a while if the commander is down
+		timer.Stop()
+	}
+	out.finish(jobID)
+
+	errmsg := ""
+	if result.err != nil {
+		errmsg = result.err.Error()
+	}
+	outcome := common.Outcome(result.exitCode, result.signal, errmsg)
+	if reason, stopped := proc.stopReason(); stopped {
+		outcome = reason
+	}
+	report := &pbMessages.JobStatusReport{
+		JobID:     jobID,
+		Attempt:   int32(attempt),
+		Worker:    ID,
+		Status:    int32(outcome),
+		Time:      result.end.UnixNano(),
+		ExitCode:  int32(result.exitCode),
+		Output:    out.Tail(pbMessages.OutputStream_STDOUT, reportOutputSize),
+		Stderr:    out.Tail(pbMessages.OutputStream_STDERR, reportOutputSize),
+		StartTime: result.start.UnixNano(),
+		Signal:    result.signal,
+		Error:     errmsg,
+	}
+	// keep trying, the commander may be restarting
+	reportStatus(report, -1)
+	// until now a Work message sent again for this attempt is ignored
+	removeProcess(jobID, proc)
+	releaseLease(jobID)
+}
+
+// runGobJob runs a job sent gob encoded by a commander which predates the
+// JobStatusService, and so waits for the job to finish and takes its results
+// from the reply
+func runGobJob(jobID int32, job *common.Job) (*pbMessages.WorkResponse, error) {
+	proc, fresh := newProcess(jobID, job.Attempt(), job.KillGrace)
+	if !fresh {
+		return nil, status.Errorf(codes.AlreadyExists, "job %d is already running here", jobID)
+	}
+	out := newJobOutput(jobID)
+	var result *cmdResult
+	done := make(chan struct{})
+	run := func() {
+		result = executeCmd(job, out, proc, func(time.Time) {})
+		out.finish(jobID)
+		removeProcess(jobID, proc)
+		close(done)
+	}
+	if !runInSlot(run) {
+		removeProcess(jobID, proc)
+		removeJobOutput(jobID, out)
+		used, queued := slotUsage()
+		return nil, status.Errorf(codes.ResourceExhausted, "all %d slots are busy and %d jobs are queued", used, queued)
+	}
+	<-done
+
+	response := &pbMessages.WorkResponse{
+		JobID:     jobID,
+		Output:    out.Tail(pbMessages.OutputStream_STDOUT, reportOutputSize),
+		ExitCode:  int32(result.exitCode),
+		Stderr:    out.Tail(pbMessages.OutputStream_STDERR, reportOutputSize),
+		StartTime: result.start.UnixNano(),
+		EndTime:   result.end.UnixNano(),
+		Signal:    result.signal,
+	}
+	if result.err != nil {
+		response.Error = result.err.Error()
+	}
+	return response, nil
+}
+
+// reportStatus sends a report to the commander, making up to attempts tries
+// (or trying forever if attempts is negative) while it cannot be reached
+func reportStatus(report *pbMessages.JobStatusReport, attempts int) {
+	connStr := fmt.Sprintf("%s:50050", Server)
+	for attempt := 0; attempts < 0 || attempt < attempts; attempt++ {
+		err := SendStatusReport(connStr, report)
+		if err == nil {
+			return
+		}
+		code := status.Code(err)
+		if code != codes.Unavailable && code != codes.DeadlineExceeded {
+			log.Printf("Status report for job %d rejected: %v\n", report.JobID, err)
+			return
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+func SendStatusReport(connString string, message *pbMessages.JobStatusReport) error {
+	var networkclient pbMessages.JobStatusServiceClient
+	if cs := currentSession(); cs != nil {
+		// results go down the session when there is one
+		networkclient = cs
+	} else {
+		cc, err := getConn(connString)
+		if err != nil {
+			log.Printf("gRPC dial error: %v\n", err)
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		networkclient = pbMessages.NewJobStatusServiceClient(cc)
+	}
+	response, err := networkclient.ReportStatus(context.Background(), message)
+	if err != nil {
+		log.Printf("SendStatusReport() failed: %v\n", err)
+		return err
+	} else {
+		if response != nil {
+			if DebugLog {
+				fmt.Printf("Sent 'JobStatusReport' to 'ReportStatus' service, received 'JobStatusAck'\n")
+			}
+		}
+	}
+	return nil
+}
+
+// executeCmd runs a job's command to completion as proc, writing its output
+// to out and calling started once the process is up
+func executeCmd(job *common.Job, out *jobOutput, proc *process, started func(time.Time)) *cmdResult {
+
+	cmd := exec.Command(job.Command, job.Args...)
+	if DebugLog {
+		fmt.Printf("cmd string: %s %v\n", job.Command, job.Args)
+	}
+	cmd.Dir = job.WorkingDir
+	if len(job.Env) > 0 {
+		cmd.Env = os.Environ()
+		for key, value := range job.Env {
+			cmd.Env = append(cmd.Env, key+"="+value)
+		}
+	}
+	//cmd.Stdin = strings.NewReader("some input")
+	cmd.Stdout = out.writer(pbMessages.OutputStream_STDOUT)
+	cmd.Stderr = out.writer(pbMessages.OutputStream_STDERR)
+
+	result := &cmdResult{start: time.Now()}
+	err := proc.start(cmd)
+	if err != nil {
+		// never started, e.g. the command does not exist
+		log.Printf("CMD ERROR: %v\n", err)
+		result.end = time.Now()
+		result.err = err
+		result.exitCode = -1
+		return result
+	}
+	started(result.start)
+
+	err = proc.wait()
+	result.end = time.Now()
+
+	result.exitCode = cmd.ProcessState.ExitCode()
+	waitStatus, ok := cmd.ProcessState.Sys().(syscall.WaitStatus)
+	if ok && waitStatus.Signaled() {
+		result.signal = waitStatus.Signal().String()
+	}
+	if err != nil && DebugLog {
+		fmt.Printf("CMD ERROR: %v\n", err)
+	}
+
+	return result
+}
@@ -0,0 +1,176 @@
+package worker
+
+import (
+	"common"
+	"context"
+	"fmt"
+	"log"
+	"pbMessages"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Features a worker in pull mode can offer, the commander has no way to reach
+// it to stream output
+var pullFeatures = []string{common.FEATURE_CANCEL}
+
+// Jobs leased from the commander in pull mode and their attempt numbers, held
+// until the job's final status has been reported
+var (
+	leases    = make(map[int32]int32)
+	leasesMtx sync.Mutex
+)
+
+// releaseLease stops renewing the lease on a job
+func releaseLease(jobID int32) {
+	leasesMtx.Lock()
+	delete(leases, jobID)
+	leasesMtx.Unlock()
+}
+
+func heldLeases() []*pbMessages.Lease {
+	leasesMtx.Lock()
+	defer leasesMtx.Unlock()
+	var held []*pbMessages.Lease
+	for jobID, attempt := range leases {
+		held = append(held, &pbMessages.Lease{JobID: jobID, Attempt: attempt})
+	}
+	return held
+}
+
+// RunPullProtocol says hello in pull mode, then leases work whenever it has
+// room for more, renewing the leases on every job it holds
+func RunPullProtocol(server string, wg *sync.WaitGroup) {
+	connStr := fmt.Sprintf("%s:50050", server)
+	var lastHello, lastRenew time.Time
+	renewEvery := 10 * time.Second
+
+	for true {
+		if time.Since(lastHello) > 20*time.Second {
+			pMessage := helloRequest(server)
+			pMessage.Mode = "pull"
+			pMessage.Features = pullFeatures
+			if SendHelloMessage(connStr, pMessage) {
+				lastHello = time.Now()
+			} else {
+				log.Println("Sending HelloRequest failed.")
+			}
+		}
+
+		if time.Since(lastRenew) > renewEvery {
+			duration, err := renewLeases(connStr)
+			if err == nil {
+				lastRenew = time.Now()
+				renewEvery = duration / 3
+			}
+			if status.Code(err) == codes.FailedPrecondition {
+				// the commander has forgotten us
+				lastHello = time.Time{}
+			}
+		}
+
+		err := leaseWork(connStr)
+		if status.Code(err) == codes.FailedPrecondition {
+			lastHello = time.Time{}
+		}
+
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// leaseWork asks the commander for as many jobs as there is room for and
+// starts them
+func leaseWork(connString string) error {
+	leasesMtx.Lock()
+	capacity := Slots - len(leases)
+	leasesMtx.Unlock()
+	if capacity <= 0 {
+		return nil
+	}
+
+	pMessage := &pbMessages.LeaseWorkRequest{Worker: ID, Capacity: int32(capacity), Resources: resources()}
+	response, err := SendLeaseWorkMessage(connString, pMessage)
+	if err != nil {
+		return err
+	}
+	for _, request := range response.GetJobs() {
+		leasesMtx.Lock()
+		leases[request.GetJobID()] = request.GetAttempt()
+		leasesMtx.Unlock()
+
+		_, err := (&worker{}).Work(context.Background(), request)
+		if err != nil {
+			// the lease runs out and the commander takes the job back
+			log.Printf("Leased job %d could not be started: %v\n", request.GetJobID(), err)
+			releaseLease(request.GetJobID())
+		}
+	}
+	return nil
+}
+
+// renewLeases renews the leases on every job held, stopping any the commander
+// no longer wants run, and returns how long the leases now last
+func renewLeases(connString string) (time.Duration, error) {
+	pMessage := &pbMessages.RenewLeaseRequest{Worker: ID, Leases: heldLeases(), Resources: resources()}
+	response, err := SendRenewLeaseMessage(connString, pMessage)
+	if err != nil {
+		return 0, err
+	}
+	for _, jobID := range response.GetStop() {
+		proc := getProcess(jobID)
+		if proc != nil {
+			if DebugLog {
+				fmt.Printf("Stopping job %d, the commander no longer wants it run\n", jobID)
+			}
+			proc.stop(common.CANCELLED)
+		}
+	}
+	return time.Duration(response.GetLeaseDuration()), nil
+}
+
+func SendLeaseWorkMessage(connString string, message *pbMessages.LeaseWorkRequest) (*pbMessages.LeaseWorkResponse, error) {
+	cc, err := getConn(connString)
+	if err != nil {
+		log.Printf("gRPC dial error: %v\n", err)
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	networkclient := pbMessages.NewLeaseServiceClient(cc)
+	response, err := networkclient.LeaseWork(context.Background(), message)
+	if err != nil {
+		log.Printf("SendLeaseWorkMessage() failed: %v\n", err)
+		return nil, err
+	} else {
+		if response != nil {
+			if DebugLog && len(response.GetJobs()) > 0 {
+				fmt.Printf("Sent 'LeaseWorkRequest' to 'LeaseWork' service, received %d jobs\n", len(response.GetJobs()))
+			}
+		}
+	}
+	return response, nil
+}
+
+func SendRenewLeaseMessage(connString string, message *pbMessages.RenewLeaseRequest) (*pbMessages.RenewLeaseResponse, error) {
+	cc, err := getConn(connString)
+	if err != nil {
+		log.Printf("gRPC dial error: %v\n", err)
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	networkclient := pbMessages.NewLeaseServiceClient(cc)
+	response, err := networkclient.RenewLease(context.Background(), message)
+	if err != nil {
+		log.Printf("SendRenewLeaseMessage() failed: %v\n", err)
+		return nil, err
+	} else {
+		if response != nil {
+			if DebugLog {
+				fmt.Printf("Sent 'RenewLeaseRequest' to 'RenewLease' service, received 'RenewLeaseResponse'\n")
+			}
+		}
+	}
+	return response, nil
+}
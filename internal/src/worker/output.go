@@ -0,0 +1,183 @@
+package worker
+
+import (
+	"context"
+	"pbMessages"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// How long a finished job's output can still be streamed
+const outputRetention = 1 * time.Hour
+
+// Largest piece of output sent in a single responseStdOut
+const outputChunkSize = 64 * 1024
+
+// Most of each output stream kept for a job. Once a stream grows past it the
+// oldest quarter is dropped, and can no longer be streamed.
+const outputLimit = 4 * 1024 * 1024
+
+// jobOutput collects everything a job writes to stdout and stderr, waking up
+// anyone streaming it whenever more arrives
+type jobOutput struct {
+	mtx     sync.Mutex
+	streams [2][]byte // indexed by pbMessages.OutputStream
+	dropped [2]int64  // bytes dropped from the start of each stream to stay within outputLimit
+	done    bool
+	changed chan struct{}
+}
+
+var (
+	outputs    = make(map[int32]*jobOutput)
+	outputsMtx sync.Mutex
+)
+
+// newJobOutput registers the output of a job which is about to start
+func newJobOutput(jobID int32) *jobOutput {
+	out := &jobOutput{changed: make(chan struct{})}
+	outputsMtx.Lock()
+	outputs[jobID] = out
+	outputsMtx.Unlock()
+	return out
+}
+
+// removeJobOutput forgets the output of a job, unless it has been replaced by
+// that of another run of the job
+func removeJobOutput(jobID int32, out *jobOutput) {
+	outputsMtx.Lock()
+	if outputs[jobID] == out {
+		delete(outputs, jobID)
+	}
+	outputsMtx.Unlock()
+}
+
+func getJobOutput(jobID int32) *jobOutput {
+	outputsMtx.Lock()
+	defer outputsMtx.Unlock()
+	return outputs[jobID]
+}
+
+// streamWriter is the io.Writer for one of a job's output streams
+type streamWriter struct {
+	out    *jobOutput
+	stream pbMessages.OutputStream
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.out.mtx.Lock()
+	data := append(w.out.streams[w.stream], p...)
+	if len(data) > outputLimit {
+		keep := outputLimit * 3 / 4
+		w.out.dropped[w.stream] += int64(len(data) - keep)
+		data = append([]byte(nil), data[len(data)-keep:]...)
+	}
+	w.out.streams[w.stream] = data
+	close(w.out.changed)
+	w.out.changed = make(chan struct{})
+	w.out.mtx.Unlock()
+	return len(p), nil
+}
+
+func (out *jobOutput) writer(stream pbMessages.OutputStream) *streamWriter {
+	return &streamWriter{out, stream}
+}
+
+// finish marks the output complete and forgets it once outputRetention is up
+func (out *jobOutput) finish(jobID int32) {
+	out.mtx.Lock()
+	out.done = true
+	close(out.changed)
+	out.changed = make(chan struct{})
+	out.mtx.Unlock()
+
+	time.AfterFunc(outputRetention, func() {
+		// the job may have been sent here again since
+		removeJobOutput(jobID, out)
+	})
+}
+
+// Tail returns at most the last n bytes written to a stream
+func (out *jobOutput) Tail(stream pbMessages.OutputStream, n int) string {
+	out.mtx.Lock()
+	defer out.mtx.Unlock()
+	data := out.streams[stream]
+	if len(data) > n {
+		data = data[len(data)-n:]
+	}
+	return string(data)
+}
+
+// read returns the output written to each stream after the given offsets,
+// with the offsets it starts at, whether the job has finished, and a channel
+// which is closed when there is more to read. Output which has been dropped
+// is skipped over.
+func (out *jobOutput) read(offsets [2]int64) ([2][]byte, [2]int64, bool, <-chan struct{}) {
+	out.mtx.Lock()
+	defer out.mtx.Unlock()
+	var unread [2][]byte
+	for s, data := range out.streams {
+		dropped := out.dropped[s]
+		end := dropped + int64(len(data))
+		offset := offsets[s]
+		if offset < 0 || offset > end {
+			offset = end
+		}
+		if offset < dropped {
+			offset = dropped
+		}
+		unread[s] = data[offset-dropped:]
+		offsets[s] = offset
+	}
+	return unread, offsets, out.done, out.changed
+}
+
+// This function implements the StreamOutput interface
+func (*worker) StreamOutput(request *pbMessages.RequestStdOut, stream pbMessages.OutputService_StreamOutputServer) error {
+	return streamJobOutput(stream.Context(), request, stream.Send)
+}
+
+// streamJobOutput sends a job's output in chunks until it is all sent or,
+// when following, until the job finishes or ctx is done
+func streamJobOutput(ctx context.Context, request *pbMessages.RequestStdOut, send func(*pbMessages.ResponseStdOut) error) error {
+	out := getJobOutput(request.GetJobID())
+	if out == nil {
+		return status.Errorf(codes.NotFound, "no output for job %d", request.GetJobID())
+	}
+
+	offsets := [2]int64{request.GetStdoutOffset(), request.GetStderrOffset()}
+	for {
+		unread, start, done, changed := out.read(offsets)
+		offsets = start
+		for s, data := range unread {
+			for len(data) > 0 {
+				chunk := data
+				if len(chunk) > outputChunkSize {
+					chunk = chunk[:outputChunkSize]
+				}
+				err := send(&pbMessages.ResponseStdOut{
+					JobID:  request.GetJobID(),
+					Data:   string(chunk),
+					Stream: pbMessages.OutputStream(s),
+					Offset: offsets[s],
+				})
+				if err != nil {
+					return err
+				}
+				offsets[s] += int64(len(chunk))
+				data = data[len(chunk):]
+			}
+		}
+
+		if done || !request.GetFollow() {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package worker
+
+import (
+	"common"
+	"errors"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// How long a stopped job has to exit after SIGTERM before it is killed, if
+// the job does not say
+const defaultKillGrace = 10 * time.Second
+
+var errStopped = errors.New("job was stopped before it started")
+
+// process is the running command of a job, kept so it can be stopped early
+type process struct {
+	mtx     sync.Mutex
+	cmd     *exec.Cmd
+	attempt int // which run of the job it is
+	exited  chan struct{}
+	grace   time.Duration
+	stopped bool
+	reason  common.Status // what the job ends as if it was stopped
+}
+
+var (
+	processes    = make(map[int32]*process)
+	processesMtx sync.Mutex
+)
+
+// newProcess registers a job which is about to be run, returning false if the
+// same attempt at it is already here and has not been stopped
+func newProcess(jobID int32, attempt int, grace time.Duration) (*process, bool) {
+	if grace <= 0 {
+		grace = defaultKillGrace
+	}
+	processesMtx.Lock()
+	defer processesMtx.Unlock()
+	if existing := processes[jobID]; existing != nil && existing.attempt == attempt {
+		if _, stopped := existing.stopReason(); !stopped {
+			return existing, false
+		}
+	}
+	proc := &process{attempt: attempt, exited: make(chan struct{}), grace: grace}
+	processes[jobID] = proc
+	return proc, true
+}
+
+func getProcess(jobID int32) *process {
+	processesMtx.Lock()
+	defer processesMtx.Unlock()
+	return processes[jobID]
+}
+
+// removeProcess forgets a job once it has exited and been reported
+func removeProcess(jobID int32, proc *process) {
+	processesMtx.Lock()
+	if processes[jobID] == proc {
+		delete(processes, jobID)
+	}
+	processesMtx.Unlock()
+}
+
+// start runs cmd in its own process group, unless the job was stopped first
+func (proc *process) start(cmd *exec.Cmd) error {
+	proc.mtx.Lock()
+	defer proc.mtx.Unlock()
+	if proc.stopped {
+		close(proc.exited)
+		return errStopped
+	}
+	setProcessGroup(cmd)
+	err := cmd.Start()
+	if err != nil {
+		close(proc.exited)
+		return err
+	}
+	proc.cmd = cmd
+	return nil
+}
+
+// wait waits for a started command to exit
+func (proc *process) wait() error {
+	err := proc.cmd.Wait()
+	proc.mtx.Lock()
+	close(proc.exited)
+	proc.mtx.Unlock()
+	return err
+}
+
+// hasExited reports whether the command has exited, after which its process
+// group ID may belong to something else. proc.mtx must be held.
+func (proc *process) hasExited() bool {
+	select {
+	case <-proc.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+// stop asks the job's process group to terminate, killing it if it is still
+// around after the grace period. The job will end as reason, unless it has
+// already exited, in which case false is returned.
+func (proc *process) stop(reason common.Status) bool {
+	proc.mtx.Lock()
+	if proc.stopped || proc.hasExited() {
+		stopped := proc.stopped
+		proc.mtx.Unlock()
+		return stopped
+	}
+	proc.stopped = true
+	proc.reason = reason
+	cmd := proc.cmd
+	if cmd != nil {
+		terminateProcessGroup(cmd)
+	}
+	proc.mtx.Unlock()
+
+	if cmd == nil {
+		// not started yet, start() will refuse to
+		return true
+	}
+	go func() {
+		select {
+		case <-proc.exited:
+		case <-time.After(proc.grace):
+			proc.mtx.Lock()
+			if !proc.hasExited() {
+				killProcessGroup(cmd)
+			}
+			proc.mtx.Unlock()
+		}
+	}()
+	return true
+}
+
+// stopReason returns what a stopped job ends as, and whether it was stopped
+func (proc *process) stopReason() (common.Status, bool) {
+	proc.mtx.Lock()
+	defer proc.mtx.Unlock()
+	return proc.reason, proc.stopped
+}
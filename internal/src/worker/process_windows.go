@@ -0,0 +1,23 @@
+// Process groups on Windows OSes
+
+//go:build windows
+// +build windows
+
+package worker
+
+import (
+	"os/exec"
+)
+
+// Windows has no process groups to signal, so only the command itself is
+// stopped and there is no gentler request than killing it
+func setProcessGroup(cmd *exec.Cmd) {
+}
+
+func terminateProcessGroup(cmd *exec.Cmd) {
+	cmd.Process.Kill()
+}
+
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.Process.Kill()
+}
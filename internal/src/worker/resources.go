@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"common"
+	"pbMessages"
+	"runtime"
+)
+
+// Slots is how many jobs the worker runs at once, at least 1
+var Slots int
+
+// resources returns what the worker has to run jobs with and how many of its
+// slots are in use as things stand, advertised in HelloRequest and refreshed
+// with every heartbeat
+func resources() *pbMessages.WorkerResources {
+	totalMemory, freeMemory := memory()
+	totalDisk, freeDisk := disk(".")
+	used, queued := slotUsage()
+	capacity := common.Capacity{
+		CPUs:        runtime.NumCPU(),
+		TotalMemory: totalMemory,
+		FreeMemory:  freeMemory,
+		TotalDisk:   totalDisk,
+		FreeDisk:    freeDisk,
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		Slots:       Slots,
+		SlotsUsed:   used,
+		Queued:      queued,
+		QueueSize:   QueueSize,
+	}
+	return capacity.Spec()
+}
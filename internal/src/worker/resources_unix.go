@@ -0,0 +1,55 @@
+// Memory and disk space on unix OSes
+
+//go:build !windows
+// +build !windows
+
+package worker
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// memory returns the total and available memory in bytes from /proc/meminfo,
+// or 0s where there is no such file
+func memory() (int64, int64) {
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, 0
+	}
+	defer file.Close()
+
+	var total, free int64
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		kb, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "MemTotal:":
+			total = kb * 1024
+		case "MemAvailable:":
+			free = kb * 1024
+		}
+	}
+	return total, free
+}
+
+// disk returns the total and available space in bytes of the filesystem path
+// is on
+func disk(path string) (int64, int64) {
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return 0, 0
+	}
+	return int64(stat.Blocks) * int64(stat.Bsize), int64(stat.Bavail) * int64(stat.Bsize)
+}
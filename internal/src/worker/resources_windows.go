@@ -0,0 +1,16 @@
+// Memory and disk space on Windows OSes
+
+//go:build windows
+// +build windows
+
+package worker
+
+// memory is not advertised on Windows, so memory requests are not checked
+func memory() (int64, int64) {
+	return 0, 0
+}
+
+// disk space is not advertised on Windows, so disk requests are not checked
+func disk(path string) (int64, int64) {
+	return 0, 0
+}
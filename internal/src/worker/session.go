@@ -0,0 +1,227 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"pbMessages"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// commanderSession is the Connect stream a worker in session mode opens to
+// the commander, so it never has to accept connections itself
+type commanderSession struct {
+	stream  pbMessages.SessionService_ConnectClient
+	sendMtx sync.Mutex
+	mtx     sync.Mutex
+	lastID  int64
+	pending map[int64]chan *pbMessages.CommanderMessage
+	streams map[int64]context.CancelFunc // output being streamed to the commander
+	ctx     context.Context
+}
+
+var (
+	session    *commanderSession
+	sessionMtx sync.Mutex
+)
+
+// currentSession returns the open session, or nil if there is none
+func currentSession() *commanderSession {
+	sessionMtx.Lock()
+	defer sessionMtx.Unlock()
+	return session
+}
+
+func setSession(cs *commanderSession) {
+	sessionMtx.Lock()
+	session = cs
+	sessionMtx.Unlock()
+}
+
+func (cs *commanderSession) send(message *pbMessages.WorkerMessage) error {
+	cs.sendMtx.Lock()
+	defer cs.sendMtx.Unlock()
+	return cs.stream.Send(message)
+}
+
+// reply answers a request from the commander
+func (cs *commanderSession) reply(id int64, message *pbMessages.WorkerMessage, err error) {
+	message.ReplyTo = id
+	if err != nil {
+		// a failed request has no response to carry
+		message.Message = nil
+		message.ErrorCode = int32(status.Code(err))
+		message.Error = status.Convert(err).Message()
+		message.End = true
+	}
+	err = cs.send(message)
+	if err != nil && DebugLog {
+		log.Printf("Replying to the commander failed: %v\n", err)
+	}
+}
+
+// ReportStatus sends a status report down the session and waits for the
+// commander to acknowledge it, so a session can stand in for a
+// JobStatusServiceClient
+func (cs *commanderSession) ReportStatus(ctx context.Context, in *pbMessages.JobStatusReport, opts ...grpc.CallOption) (*pbMessages.JobStatusAck, error) {
+	cs.mtx.Lock()
+	cs.lastID += 1
+	id := cs.lastID
+	replies := make(chan *pbMessages.CommanderMessage, 1)
+	cs.pending[id] = replies
+	cs.mtx.Unlock()
+	defer func() {
+		cs.mtx.Lock()
+		delete(cs.pending, id)
+		cs.mtx.Unlock()
+	}()
+
+	err := cs.send(&pbMessages.WorkerMessage{Id: id, Message: &pbMessages.WorkerMessage_Status{Status: in}})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "session with commander failed: %v", err)
+	}
+	select {
+	case reply := <-replies:
+		if reply.GetErrorCode() != int32(codes.OK) {
+			return nil, status.Error(codes.Code(reply.GetErrorCode()), reply.GetError())
+		}
+		return reply.GetStatus(), nil
+	case <-cs.ctx.Done():
+		return nil, status.Error(codes.Unavailable, "session with commander closed")
+	case <-ctx.Done():
+		return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+	}
+}
+
+// handle deals with one message from the commander
+func (cs *commanderSession) handle(message *pbMessages.CommanderMessage) {
+	if message.GetHangUp() {
+		cs.mtx.Lock()
+		cancel, found := cs.streams[message.GetReplyTo()]
+		cs.mtx.Unlock()
+		if found {
+			cancel()
+		}
+		return
+	}
+	if message.GetReplyTo() != 0 {
+		cs.mtx.Lock()
+		replies, found := cs.pending[message.GetReplyTo()]
+		cs.mtx.Unlock()
+		if found {
+			replies <- message
+		}
+		return
+	}
+
+	id := message.GetId()
+	switch request := message.Message.(type) {
+	case *pbMessages.CommanderMessage_Ping:
+		pong, err := (&worker{}).Heartbeat(cs.ctx, request.Ping)
+		cs.reply(id, &pbMessages.WorkerMessage{Message: &pbMessages.WorkerMessage_Pong{Pong: pong}}, err)
+	case *pbMessages.CommanderMessage_Work:
+		go func() {
+			response, err := (&worker{}).Work(cs.ctx, request.Work)
+			cs.reply(id, &pbMessages.WorkerMessage{Message: &pbMessages.WorkerMessage_Work{Work: response}}, err)
+		}()
+	case *pbMessages.CommanderMessage_Cancel:
+		go func() {
+			response, err := (&worker{}).Cancel(cs.ctx, request.Cancel)
+			cs.reply(id, &pbMessages.WorkerMessage{Message: &pbMessages.WorkerMessage_Work{Work: response}}, err)
+		}()
+	case *pbMessages.CommanderMessage_Output:
+		go cs.streamOutput(id, request.Output)
+	default:
+		cs.reply(id, &pbMessages.WorkerMessage{}, status.Errorf(codes.Unimplemented, "unexpected %T in session", request))
+	}
+}
+
+// streamOutput sends a job's output down the session until it is all sent or
+// the commander hangs up
+func (cs *commanderSession) streamOutput(id int64, request *pbMessages.RequestStdOut) {
+	ctx, cancel := context.WithCancel(cs.ctx)
+	cs.mtx.Lock()
+	cs.streams[id] = cancel
+	cs.mtx.Unlock()
+	defer func() {
+		cs.mtx.Lock()
+		delete(cs.streams, id)
+		cs.mtx.Unlock()
+		cancel()
+	}()
+
+	err := streamJobOutput(ctx, request, func(chunk *pbMessages.ResponseStdOut) error {
+		return cs.send(&pbMessages.WorkerMessage{ReplyTo: id, Message: &pbMessages.WorkerMessage_Output{Output: chunk}})
+	})
+	if ctx.Err() != nil {
+		// nobody is listening
+		return
+	}
+	cs.reply(id, &pbMessages.WorkerMessage{End: true}, err)
+}
+
+// RunSession keeps a session with the commander open, reconnecting whenever
+// it is lost
+func RunSession(server string, wg *sync.WaitGroup) {
+	for true {
+		err := runSession(server)
+		if status.Code(err) == codes.FailedPrecondition {
+			log.Printf("Commander rejected this worker: %s\n", status.Convert(err).Message())
+		} else {
+			log.Printf("Session with commander ended: %v\n", err)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// runSession opens a session, says hello and serves the commander's requests
+// until the session ends
+func runSession(server string) error {
+	cc, err := getConn(fmt.Sprintf("%s:50050", server))
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	networkclient := pbMessages.NewSessionServiceClient(cc)
+	stream, err := networkclient.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	cs := &commanderSession{
+		stream:  stream,
+		pending: make(map[int64]chan *pbMessages.CommanderMessage),
+		streams: make(map[int64]context.CancelFunc),
+		ctx:     ctx,
+	}
+
+	cs.lastID = 1
+	err = cs.send(&pbMessages.WorkerMessage{Id: cs.lastID, Message: &pbMessages.WorkerMessage_Hello{Hello: helloRequest(server)}})
+	if err != nil {
+		return err
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if DebugLog {
+		fmt.Printf("Opened session with the commander\n")
+		fmt.Printf("Negotiated protocol version %d with features %v\n", first.GetHello().GetVersion(), first.GetHello().GetFeatures())
+	}
+
+	setSession(cs)
+	defer setSession(nil)
+	for {
+		message, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		cs.handle(message)
+	}
+}
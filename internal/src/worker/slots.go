@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"sync"
+)
+
+// QueueSize is how many jobs the worker holds on to while every slot is busy,
+// any more are turned away
+var QueueSize int
+
+// The jobs taking up a slot and those waiting for one, in the order they
+// arrived
+var (
+	slotsUsed int
+	queue     []func()
+	slotsMtx  sync.Mutex
+)
+
+// runInSlot runs a job in a free slot, or queues it until one frees up,
+// returning false if the queue is full too
+func runInSlot(run func()) bool {
+	slotsMtx.Lock()
+	defer slotsMtx.Unlock()
+	if slotsUsed < Slots {
+		slotsUsed += 1
+		go runThenFree(run)
+		return true
+	}
+	if len(queue) < QueueSize {
+		queue = append(queue, run)
+		return true
+	}
+	return false
+}
+
+// runThenFree runs a job, then hands its slot to the next job in the queue
+func runThenFree(run func()) {
+	for run != nil {
+		run()
+
+		slotsMtx.Lock()
+		run = nil
+		if len(queue) > 0 {
+			run, queue = queue[0], queue[1:]
+		} else {
+			slotsUsed -= 1
+		}
+		slotsMtx.Unlock()
+	}
+}
+
+// slotUsage returns how many slots are busy and how many jobs are queued
+func slotUsage() (int, int) {
+	slotsMtx.Lock()
+	defer slotsMtx.Unlock()
+	return slotsUsed, len(queue)
+}
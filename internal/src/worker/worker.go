@@ -8,20 +8,31 @@ import (
 	"fmt"
 	"log"
 	"net"
-	"os/exec"
+	"os"
 	"sync"
 	"time"
 
 	"pbMessages"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
 	DebugLog bool
+	Server   string
+	Labels   map[string]string // advertised to the commander for jobs to select on
 )
 
-const helloVersion = 1
+// Range of protocol versions the worker can talk to the commander with
+const (
+	minHelloVersion = common.PROTOCOL_V1
+	helloVersion    = common.PROTOCOL_V3
+)
+
+// Features the worker offers the commander
+var helloFeatures = []string{common.FEATURE_CANCEL, common.FEATURE_OUTPUT_STREAM}
 
 type worker struct {
 }
@@ -29,15 +40,58 @@ type worker struct {
 func (*worker) Heartbeat(ctx context.Context, request *pbMessages.Ping) (*pbMessages.Pong, error) {
 	name := request.GetName()
 	response := &pbMessages.Pong{
-		Name: name,
+		Name:      name,
+		Resources: resources(),
 	}
 	return response, nil
 }
 
 func (*worker) Work(ctx context.Context, request *pbMessages.WorkRequest) (*pbMessages.WorkResponse, error) {
+	if request.GetSpec() == nil {
+		// older commanders send a gob encoded common.Job
+		job, err := decodeGobJob(request.GetJob())
+		if err != nil {
+			fmt.Printf("gob decode error: %v", err)
+			return nil, status.Errorf(codes.InvalidArgument, "cannot decode job %d: %v", request.GetJobID(), err)
+		}
+		return runGobJob(request.GetJobID(), job)
+	}
+	job := common.JobFromSpec(request.GetSpec())
+	attempt := int(request.GetAttempt())
+
+	response := &pbMessages.WorkResponse{
+		JobID: request.GetJobID(),
+	}
+
+	// registered up front so the output can be streamed, and the job
+	// cancelled, straight away, even while it is queued
+	proc, fresh := newProcess(request.GetJobID(), attempt, job.KillGrace)
+	if !fresh {
+		// sent again as the reply to the first Work was lost
+		if DebugLog {
+			fmt.Printf("Job %d attempt %d is already here\n", request.GetJobID(), attempt)
+		}
+		return response, nil
+	}
+	out := newJobOutput(request.GetJobID())
+	run := func() {
+		runJob(request.GetJobID(), attempt, job, out, proc)
+	}
+	if !runInSlot(run) {
+		removeProcess(request.GetJobID(), proc)
+		removeJobOutput(request.GetJobID(), out)
+		used, queued := slotUsage()
+		return nil, status.Errorf(codes.ResourceExhausted, "all %d slots are busy and %d jobs are queued", used, queued)
+	}
+
+	// the job's progress is reported through the JobStatusService
+	return response, nil
+}
+
+// decodeGobJob decodes the job payload sent by commanders which predate the
+// protocol buffers job definition
+func decodeGobJob(byteData []byte) (*common.Job, error) {
 	// job data is []byte, but needs to be bytes.buffer for deserialisation
-	byteData := request.GetJob()
-	// create bytes.buffer
 	buffer := bytes.NewBuffer(byteData)
 
 	// deserialise into job struct
@@ -45,21 +99,35 @@ func (*worker) Work(ctx context.Context, request *pbMessages.WorkRequest) (*pbMe
 	var job common.Job
 	err := decoder.Decode(&job)
 	if err != nil {
-		fmt.Printf("gob decode error: %v", err)
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (*worker) Cancel(ctx context.Context, request *pbMessages.CancelJobRequest) (*pbMessages.WorkResponse, error) {
+	proc := getProcess(request.GetJobID())
+	if proc == nil {
+		return nil, status.Errorf(codes.NotFound, "job %d is not running here", request.GetJobID())
+	}
+	if DebugLog {
+		fmt.Printf("Cancelling job %d\n", request.GetJobID())
 	}
+	if !proc.stop(common.CANCELLED) {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %d has already finished", request.GetJobID())
+	}
+
 	response := &pbMessages.WorkResponse{
-		Output: executeCmd(job.Command, job.Args),
+		JobID: request.GetJobID(),
 	}
 	return response, nil
 }
 
 func RunHelloProtocol(server string, wg *sync.WaitGroup) {
 	for true {
-		localAddr := common.GetOutboundIP(server)
 		connStr := fmt.Sprintf("%s:50050", server)
 		sent := false
 
-		pMessage := &pbMessages.HelloRequest{Version: 1, Ip: localAddr}
+		pMessage := helloRequest(server)
 		sent = SendHelloMessage(connStr, pMessage)
 		if !sent {
 			log.Println("Sending HelloRequest failed.")
@@ -69,28 +137,58 @@ func RunHelloProtocol(server string, wg *sync.WaitGroup) {
 	}
 }
 
+// helloRequest builds the HelloRequest offering every protocol version and
+// feature the worker supports
+func helloRequest(server string) *pbMessages.HelloRequest {
+	localAddr := common.GetOutboundIP(server)
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Printf("ERROR: %v\n", err)
+	}
+	pMessage := &pbMessages.HelloRequest{
+		Version:   minHelloVersion,
+		Id:        ID,
+		Ip:        localAddr,
+		Fqdn:      hostname,
+		Addresses: common.GetLocalAddresses(),
+		Features:  helloFeatures,
+		Labels:    Labels,
+		Resources: resources(),
+	}
+	for ver := minHelloVersion; ver <= helloVersion; ver++ {
+		pMessage.SupportedVersions = append(pMessage.SupportedVersions, ver)
+	}
+	return pMessage
+}
+
 func SendHelloMessage(connString string, message *pbMessages.HelloRequest) bool {
-	opts := grpc.WithInsecure()
-	cc, err := grpc.Dial(connString, opts)
+	cc, err := getConn(connString)
 	if err != nil {
 		log.Printf("gRPC dial error: %v\n", err)
 		return false
 	}
-	defer cc.Close()
 
 	networkclient := pbMessages.NewHelloServiceClient(cc)
 	response, err := networkclient.Hello(context.Background(), message)
 	if err != nil {
-		log.Printf("SendHelloMessage() failed: %v\n", err)
+		if status.Code(err) == codes.FailedPrecondition {
+			log.Printf("Commander rejected this worker: %s\n", status.Convert(err).Message())
+		} else {
+			log.Printf("SendHelloMessage() failed: %v\n", err)
+		}
 		return false
 	} else {
+		if ver := response.GetVersion(); ver < minHelloVersion || ver > helloVersion {
+			log.Printf("Commander chose protocol version %d, but this worker speaks versions %d to %d\n", ver, minHelloVersion, helloVersion)
+			return false
+		}
 		if response != nil {
 			if DebugLog {
 				fmt.Printf("Sent 'HelloRequest' to 'Hello' service, received 'HelloResponse'\n")
+				fmt.Printf("Negotiated protocol version %d with features %v\n", response.GetVersion(), response.GetFeatures())
 			}
 		}
 	}
-	cc.Close()
 	return true
 }
 
@@ -102,7 +200,7 @@ func StartHeartbeatListener(wg *sync.WaitGroup) {
 	}
 	fmt.Printf("Herd worker is listening on %v ...\n", address)
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(grpc.KeepaliveEnforcementPolicy(common.ServerKeepalive))
 	pbMessages.RegisterHeartbeatServiceServer(s, &worker{})
 
 	s.Serve(lis)
@@ -116,25 +214,11 @@ func StartWorkerListener(wg *sync.WaitGroup) {
 	}
 	fmt.Printf("Herd worker is listening on %v ...\n", address)
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(grpc.KeepaliveEnforcementPolicy(common.ServerKeepalive))
 	pbMessages.RegisterWorkServiceServer(s, &worker{})
+	pbMessages.RegisterOutputServiceServer(s, &worker{})
+	// so the commander can do everything over one connection
+	pbMessages.RegisterHeartbeatServiceServer(s, &worker{})
 
 	s.Serve(lis)
 }
-
-func executeCmd(cmdstr string, args []string) string {
-
-	cmd := exec.Command(cmdstr)
-	if DebugLog {
-		fmt.Printf("cmd string: %s\n", cmdstr)
-	}
-	//cmd.Stdin = strings.NewReader("some input")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err := cmd.Run()
-	if err != nil {
-		log.Printf("CMD ERROR: %v\n", err)
-	}
-
-	return out.String()
-}